@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/rubysolo/brows/pkg/releases"
+	"github.com/rubysolo/brows/pkg/store"
+)
+
+const identityBucket = store.BucketIdentity
+
+// identity is the GitHub login a token belongs to, cached per host so auth
+// status doesn't need an API call every time. Token is a hash of the token
+// it belongs to: once another token is in use, it's stale.
+type identity struct {
+	Source string `json:"source"`
+	Login  string `json:"login"`
+	Token  string `json:"token"`
+}
+
+func tokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// readIdentity returns the identity cached for token on the GitHub host
+// in use, dropping one cached for a different token.
+func readIdentity(token string) *identity {
+	host := githubHost(AppConfig)
+
+	id := &identity{}
+	if ok, err := AppStore.Get(identityBucket, host, id); !ok || err != nil {
+		return nil
+	}
+	if id.Token != tokenHash(token) {
+		AppStore.Delete(identityBucket, host)
+		return nil
+	}
+	return id
+}
+
+func writeIdentity(token string, id identity) error {
+	id.Token = tokenHash(token)
+	return AppStore.Put(identityBucket, githubHost(AppConfig), id)
+}
+
+func runAuth(args []string) int {
+	if len(args) == 1 && args[0] == "login" {
+		return runLogin()
+	}
+	if len(args) == 1 && args[0] == "status" {
+		return runAuthStatus()
+	}
+	if len(args) == 1 && args[0] == "logout" {
+		return runLogout()
+	}
+
+	fmt.Println("Usage:")
+	fmt.Println("  brows auth login")
+	fmt.Println("  brows auth status")
+	fmt.Println("  brows auth logout")
+	return 1
+}
+
+// runLogout removes every token brows stores itself. Tokens from the
+// environment are outside its control, so it only points those out.
+func runLogout() int {
+	status := 0
+
+	if err := keychainDelete(githubHost(AppConfig)); err != nil && err != errKeychainUnavailable {
+		fmt.Fprintln(os.Stderr, "Could not remove keychain token:", err)
+		status = 1
+	} else if err == nil {
+		fmt.Println("Removed token from keychain (if present).")
+	}
+
+	removed, err := removeConfigKey("token")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Could not remove token from config:", err)
+		status = 1
+	} else if removed {
+		fmt.Printf("Removed token from %s.\n", configFile())
+	}
+
+	if ok, _ := AppStore.Get(identityBucket, githubHost(AppConfig), &identity{}); ok {
+		if err := AppStore.Delete(identityBucket, githubHost(AppConfig)); err != nil {
+			fmt.Fprintln(os.Stderr, "Could not clear cached identity:", err)
+			status = 1
+		} else {
+			fmt.Println("Cleared cached identity.")
+		}
+	}
+
+	for _, source := range tokenSources(AppConfig) {
+		if envSource(source) && os.Getenv(source) != "" {
+			fmt.Printf("Note: %s is still set in your environment.\n", source)
+		}
+	}
+
+	return status
+}
+
+func runAuthStatus() int {
+	for _, source := range tokenSources(AppConfig) {
+		token, err := lookupToken(source, AppConfig)
+		switch {
+		case err != nil:
+			fmt.Printf("  %-20s unavailable (%v)\n", source, err)
+		case token == "":
+			fmt.Printf("  %-20s not set\n", source)
+		default:
+			fmt.Printf("  %-20s set\n", source)
+		}
+	}
+
+	token, source, err := findToken(tokenSources(AppConfig), AppConfig)
+	if err != nil {
+		fmt.Println()
+		fmt.Fprintln(os.Stderr, err)
+		if errors.Is(err, errNoToken) {
+			fmt.Fprintln(os.Stderr, "Public repositories are browsed anonymously, at GitHub's lower rate limit of 60 requests an hour.")
+		}
+		return 1
+	}
+
+	fmt.Printf("\nActive credential source: %s\n", source)
+
+	if id := readIdentity(token); id != nil && id.Source == source {
+		fmt.Printf("Logged in as %s (cached)\n", id.Login)
+		return 0
+	}
+
+	client, err := newGitHubClient(AppConfig, token)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	user, _, err := client.Users.Get(context.Background(), "")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Token check failed:", err)
+		return 1
+	}
+
+	fmt.Printf("Logged in as %s\n", user.GetLogin())
+	writeIdentity(token, identity{Source: source, Login: user.GetLogin()})
+
+	scopes, classic, err := releases.NewGitHubProvider(client).TokenScopes(context.Background())
+	switch {
+	case err != nil:
+		fmt.Fprintln(os.Stderr, "Scope check failed:", err)
+	case !classic:
+		fmt.Println("Fine-grained token: it sees only the repositories it was granted")
+	default:
+		list := strings.Join(scopes, ", ")
+		if list == "" {
+			list = "(none)"
+		}
+		fmt.Printf("Token scopes: %s\n", list)
+		if !slices.Contains(scopes, "repo") {
+			fmt.Println("Without the repo scope, private repositories look like they don't exist (for gh users: gh auth refresh -s repo)")
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+	"sync/atomic"
+
+	"github.com/rubysolo/brows/pkg/store"
+)
+
+// servedOffline is set once a response has come from the cache because
+// the network couldn't be reached, or --offline kept brows off it.
+var servedOffline atomic.Bool
+
+// errNotCached is returned offline for requests never made online.
+var errNotCached = errors.New("not available offline: never fetched before (run once online)")
+
+// cacheTransport keeps GET responses that carry an ETag or Last-Modified
+// in the store, one entry per request, and revalidates them with
+// conditional requests. GitHub doesn't count a 304 against the rate limit,
+// so re-opening a repository costs nothing when nothing changed. When the
+// network is unreachable, the rate limit is spent, or in offline mode, the
+// cached response is served as it is.
+type cacheTransport struct {
+	st   *store.Store
+	base http.RoundTripper
+
+	// refresh ignores what's cached, replacing it with fresh responses.
+	refresh bool
+	// offline answers from the cache alone, without touching the network.
+	offline bool
+}
+
+// cacheKey separates requests by URL and credentials, so one token's
+// private data is never served to another.
+func cacheKey(req *http.Request) string {
+	sum := sha256.Sum256([]byte(req.URL.String() + "\n" + req.Header.Get("Accept") + "\n" + req.Header.Get("Authorization") + "\n" + req.Header.Get("PRIVATE-TOKEN")))
+	return hex.EncodeToString(sum[:])
+}
+
+// cached reads the stored response for req, if there is one.
+func (t cacheTransport) cached(req *http.Request) (*http.Response, bool) {
+	var in []byte
+	if ok, err := t.st.Get(store.BucketResponses, cacheKey(req), &in); !ok || err != nil {
+		return nil, false
+	}
+
+	resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(in)), req)
+	if err != nil {
+		return nil, false
+	}
+	return resp, true
+}
+
+// store saves a copy of resp for req, leaving resp readable by the caller.
+func (t cacheTransport) store(req *http.Request, resp *http.Response) {
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+
+	copied := *resp
+	copied.Body = io.NopCloser(bytes.NewReader(body))
+	out, err := httputil.DumpResponse(&copied, true)
+	if err != nil {
+		return
+	}
+
+	if err := t.st.Put(store.BucketResponses, cacheKey(req), out); err != nil {
+		slog.Debug("response cache unavailable", "err", err)
+	}
+}
+
+// cacheable is a successful JSON response that can be revalidated; asset
+// downloads and the like aren't worth keeping.
+func cacheable(resp *http.Response) bool {
+	return resp.StatusCode == http.StatusOK &&
+		strings.Contains(resp.Header.Get("Content-Type"), "json") &&
+		(resp.Header.Get("ETag") != "" || resp.Header.Get("Last-Modified") != "")
+}
+
+func (t cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != "GET" {
+		return t.base.RoundTrip(req)
+	}
+
+	if t.offline {
+		resp, ok := t.cached(req)
+		if !ok {
+			return nil, errNotCached
+		}
+		servedOffline.Store(true)
+		return resp, nil
+	}
+
+	var stored *http.Response
+	if !t.refresh {
+		if resp, ok := t.cached(req); ok {
+			stored = resp
+			req = req.Clone(req.Context())
+			if etag := resp.Header.Get("ETag"); etag != "" {
+				req.Header.Set("If-None-Match", etag)
+			}
+			if modified := resp.Header.Get("Last-Modified"); modified != "" {
+				req.Header.Set("If-Modified-Since", modified)
+			}
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		if stored == nil && t.refresh {
+			// --refresh skipped the cache, but it's still better than nothing
+			stored, _ = t.cached(req)
+		}
+		if stored == nil {
+			return nil, err
+		}
+		if errors.Is(err, context.Canceled) {
+			stored.Body.Close()
+			return nil, err
+		}
+
+		slog.Debug("network unavailable, serving cached response", "url", req.URL.String(), "err", err)
+		servedOffline.Store(true)
+		return stored, nil
+	}
+
+	if rateLimited(resp) {
+		// answers already fetched are better than none until the reset
+		stripRateLimit(resp.Header)
+		if stored == nil && t.refresh {
+			stored, _ = t.cached(req)
+		}
+		if stored != nil {
+			slog.Debug("rate limited, serving cached response", "url", req.URL.String())
+			resp.Body.Close()
+			stripRateLimit(stored.Header)
+			return stored, nil
+		}
+		return resp, nil
+	}
+
+	if resp.StatusCode == http.StatusNotModified && stored != nil {
+		slog.Debug("response cache hit", "url", req.URL.String())
+		resp.Body.Close()
+
+		// the 304 carries the current rate limit
+		for name, values := range resp.Header {
+			if strings.HasPrefix(name, "X-Ratelimit-") {
+				stored.Header[name] = values
+			}
+		}
+		return stored, nil
+	}
+
+	if stored != nil {
+		stored.Body.Close()
+	}
+	if cacheable(resp) {
+		t.store(req, resp)
+	}
+
+	return resp, nil
+}
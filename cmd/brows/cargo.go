@@ -0,0 +1,259 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const cratesAPI = "https://crates.io/api/v1/crates/"
+
+// cargoSections are the Cargo.toml tables that list direct dependencies.
+var cargoSections = map[string]bool{
+	"dependencies":       true,
+	"dev-dependencies":   true,
+	"build-dependencies": true,
+}
+
+// cargoDependency finds the version of the crate name pinned in the
+// Cargo.lock of the current directory, or of the workspace it's in, and
+// the GitHub repository crates.io links it to.
+func cargoDependency(name string) (dependency, error) {
+	path, err := findCargoLock()
+	if err != nil {
+		return dependency{}, err
+	}
+
+	locked, err := readCargoLock(path)
+	if err != nil {
+		return dependency{}, err
+	}
+
+	version, ok := locked[name]
+	if !ok {
+		return dependency{}, fmt.Errorf("%s is not in %s", name, path)
+	}
+
+	client, err := newHTTPClient(AppConfig)
+	if err != nil {
+		return dependency{}, err
+	}
+
+	repo, err := crateRepository(client, name)
+	if err != nil {
+		return dependency{}, err
+	}
+
+	return dependency{Name: name, Repo: repo, Version: version, Source: path}, nil
+}
+
+// findCargoLock looks for Cargo.lock in the current directory and the ones
+// above it, since a workspace's members share the lockfile at its root.
+func findCargoLock() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		path := filepath.Join(dir, "Cargo.lock")
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("no Cargo.lock here or in any parent directory (run cargo generate-lockfile)")
+		}
+		dir = parent
+	}
+}
+
+// readCargoToml lists the crates a Cargo.toml depends on directly, at the
+// versions Cargo.lock next to it pins, with their repositories looked up
+// on crates.io.
+func readCargoToml(path string) ([]dependency, error) {
+	names, err := cargoDependencies(path)
+	if err != nil {
+		return nil, err
+	}
+
+	lockPath := filepath.Join(filepath.Dir(path), "Cargo.lock")
+	locked, err := readCargoLock(lockPath)
+	if err != nil {
+		return nil, err
+	}
+
+	deps := []dependency{}
+	for _, name := range names {
+		if version, ok := locked[name]; ok {
+			deps = append(deps, dependency{Name: name, Version: version, Source: filepath.Base(path)})
+		}
+	}
+
+	client, err := newHTTPClient(AppConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return resolveRepos(deps, func(d dependency) (string, error) {
+		return crateRepository(client, d.Name)
+	}), nil
+}
+
+// cargoDependencies reads the crate names out of a Cargo.toml's
+// dependency tables: "name = ..." entries, renamed ones (their package
+// key), and [dependencies.name] tables.
+func cargoDependencies(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	seen := map[string]bool{}
+	add := func(name string) {
+		if name != "" {
+			seen[strings.Trim(name, `"'`)] = true
+		}
+	}
+
+	section := ""
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			section = strings.Trim(line, "[] ")
+			// target-specific tables: [target.'cfg(unix)'.dependencies]
+			if i := strings.LastIndex(section, "."); i >= 0 && cargoSections[section[i+1:]] && strings.HasPrefix(section, "target.") {
+				section = section[i+1:]
+			}
+			for table := range cargoSections {
+				if name, ok := strings.CutPrefix(section, table+"."); ok {
+					add(name)
+				}
+			}
+			continue
+		}
+
+		if !cargoSections[section] {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		// serde_json2 = { package = "serde_json", version = "1" }
+		if name, ok := inlineTableKey(value, "package"); ok {
+			add(name)
+			continue
+		}
+		// tokio.workspace = true
+		name, _, _ := strings.Cut(strings.TrimSpace(key), ".")
+		add(name)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// inlineTableKey is the string value of key in a TOML inline table like
+// { package = "serde_json", features = ["std"] }, going by the table's
+// own keys only, not by what its values or nested tables contain.
+func inlineTableKey(table, key string) (string, bool) {
+	table = strings.TrimSpace(table)
+	if !strings.HasPrefix(table, "{") {
+		return "", false
+	}
+
+	depth, quote, start := 0, byte(0), 1
+	for i := 1; i < len(table); i++ {
+		c := table[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[' || c == '{':
+			depth++
+		case (c == ']' || c == '}') && depth > 0:
+			depth--
+		case c == ',' || c == '}':
+			k, v, ok := strings.Cut(table[start:i], "=")
+			if ok && strings.Trim(strings.TrimSpace(k), `"'`) == key {
+				return strings.Trim(strings.TrimSpace(v), `"'`), true
+			}
+			if c == '}' {
+				return "", false
+			}
+			start = i + 1
+		}
+	}
+
+	return "", false
+}
+
+// readCargoLock maps each crate in a Cargo.lock to its version. A crate
+// locked at several versions maps to the newest.
+func readCargoLock(path string) (map[string]string, error) {
+	locked, err := readLockPackages(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %v (run cargo generate-lockfile)", path, err)
+	}
+	return locked, nil
+}
+
+// crateRepository asks crates.io where the crate name is developed.
+func crateRepository(client *http.Client, name string) (string, error) {
+	req, err := http.NewRequestWithContext(context.Background(), "GET", cratesAPI+url.PathEscape(name), nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("crates.io: %s: %s", name, resp.Status)
+	}
+
+	meta := struct {
+		Crate struct {
+			Repository string `json:"repository"`
+			Homepage   string `json:"homepage"`
+		} `json:"crate"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&meta); err != nil {
+		return "", fmt.Errorf("crates.io: %s: %v", name, err)
+	}
+
+	if repo, ok := githubRepoIn(meta.Crate.Repository, meta.Crate.Homepage); ok {
+		return repo, nil
+	}
+
+	return "", fmt.Errorf("crates.io doesn't link %s to a GitHub repository", name)
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/rubysolo/brows/pkg/releases"
+)
+
+// checkConcurrency bounds how many repositories are fetched at once.
+const checkConcurrency = 4
+
+type checkResult struct {
+	Repo string `json:"repo"`
+	// Source is the manifest a dependency was read from, if any.
+	Source string `json:"source,omitempty"`
+	releases.Behind
+	Error string `json:"error,omitempty"`
+}
+
+// runCheck reports, for each owner/repo[@version] argument, how far the
+// given version is behind the latest release. A missing version reports
+// the latest release only. It exits non-zero if any repository failed.
+func runCheck(args []string) int {
+	provider, err := newProvider()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	results := checkAll(provider, args, nil)
+
+	if *jsonOutput {
+		out, _ := json.MarshalIndent(results, "", "  ")
+		fmt.Println(string(out))
+		return checkStatus(results)
+	}
+
+	printChecks(results)
+
+	return checkStatus(results)
+}
+
+// checkStatus is the exit status for a set of results: 1 if any failed.
+func checkStatus(results []checkResult) int {
+	for _, r := range results {
+		if r.Error != "" {
+			return 1
+		}
+	}
+	return 0
+}
+
+// checkDependencies is checkAll for dependencies read from manifests. It
+// honors the project's Renovate and Dependabot ignore rules, leaving out
+// ignored dependencies and versions.
+func checkDependencies(provider releases.Provider, deps []dependency) ([]checkResult, error) {
+	rules, err := readUpdateRules()
+	if err != nil {
+		return nil, err
+	}
+
+	kept := []dependency{}
+	args := []string{}
+	policies := []updatePolicy{}
+	for _, d := range deps {
+		policy := rules.policy(d.Name)
+		if policy.skip {
+			continue
+		}
+		kept = append(kept, d)
+		args = append(args, d.arg())
+		policies = append(policies, policy)
+	}
+
+	results := checkAll(provider, args, policies)
+	for i := range results {
+		results[i].Source = kept[i].Source
+	}
+
+	return results, nil
+}
+
+// checkAll runs checkRepo for every argument, a few at a time, keeping the
+// results in argument order. policies, if given, parallels args.
+func checkAll(provider releases.Provider, args []string, policies []updatePolicy) []checkResult {
+	results := make([]checkResult, len(args))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, checkConcurrency)
+
+	for i, arg := range args {
+		wg.Add(1)
+		go func(i int, arg string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			policy := updatePolicy{}
+			if policies != nil {
+				policy = policies[i]
+			}
+			results[i] = checkRepo(provider, arg, policy)
+		}(i, arg)
+	}
+	wg.Wait()
+
+	return results
+}
+
+func printChecks(results []checkResult) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "REPO\tCURRENT\tLATEST\tSTATUS")
+	for _, r := range results {
+		state := r.Behind.String()
+		switch {
+		case r.Error != "":
+			state = "error: " + r.Error
+		case r.Current == "":
+			state = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r.Repo, orDash(r.Current), orDash(r.Latest), state)
+	}
+	w.Flush()
+}
+
+func checkRepo(provider releases.Provider, arg string, policy updatePolicy) checkResult {
+	name, current, _ := strings.Cut(arg, "@")
+	result := checkResult{Repo: name}
+
+	owner, repo, err := splitRepo(name)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Repo = owner + "/" + repo
+
+	list, err := releases.Fetch(context.Background(), releases.Ref{Owner: owner, Repo: repo}, releases.WithProvider(provider))
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	if current == "" {
+		// nothing to compare against; just report the latest release
+		behind, _ := releases.Compare("0.0.0", list)
+		result.Latest = behind.Latest
+		return result
+	}
+
+	result.Behind, err = releases.Compare(current, policy.filter(current, list))
+	if err != nil {
+		result.Error = err.Error()
+	}
+
+	return result
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,273 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rubysolo/brows/pkg/store"
+)
+
+// subcommands are what the first argument can be instead of a repository,
+// with the arguments each one takes.
+var subcommands = map[string][]string{
+	"auth":       {"login", "status", "logout"},
+	"completion": {"bash", "zsh", "fish"},
+	"config":     {"init", "path"},
+	"export":     nil,
+	"get":        nil,
+	"logout":     nil,
+	"outdated":   nil,
+	"state":      {"export", "import"},
+	"status":     nil,
+	"watch":      {"add", "remove", "list", "check"},
+}
+
+const bashCompletion = `# brows completion for bash: source <(brows completion bash)
+_brows() {
+	local IFS=$'\n'
+	COMPREPLY=($(brows __complete "${COMP_WORDS[@]:1:COMP_CWORD}" 2>/dev/null))
+}
+complete -o default -F _brows brows
+`
+
+const zshCompletion = `#compdef brows
+# brows completion for zsh: source <(brows completion zsh), or save it as
+# _brows somewhere in $fpath
+_brows() {
+	local -a candidates
+	candidates=(${(f)"$(brows __complete "${(@)words[2,CURRENT]}" 2>/dev/null)"})
+	compadd -a candidates || _files
+}
+
+if [ "$funcstack[1]" = "_brows" ]; then
+	_brows "$@"
+else
+	compdef _brows brows
+fi
+`
+
+const fishCompletion = `# brows completion for fish: brows completion fish | source, or save it
+# as ~/.config/fish/completions/brows.fish
+function __brows_complete
+	set -l words (commandline -opc)
+	brows __complete $words[2..-1] (commandline -ct) 2>/dev/null
+end
+complete -c brows -f -a '(__brows_complete)'
+`
+
+func runCompletion(args []string) int {
+	scripts := map[string]string{"bash": bashCompletion, "zsh": zshCompletion, "fish": fishCompletion}
+
+	if len(args) == 1 {
+		if script, ok := scripts[args[0]]; ok {
+			fmt.Print(script)
+			return 0
+		}
+	}
+
+	fmt.Println("Usage:")
+	fmt.Println("  brows completion bash|zsh|fish")
+	return 1
+}
+
+// runComplete answers the completion scripts: words are the command
+// line's arguments, the last being the one to complete, and it prints the
+// candidates for that one, one per line. Repositories come from pins,
+// aliases and history, tags from the response cache, so completing never
+// touches the network.
+func runComplete(words []string) int {
+	if len(words) == 0 {
+		return 0
+	}
+
+	current := words[len(words)-1]
+	for _, c := range completions(words[:len(words)-1], current) {
+		if strings.HasPrefix(c, current) {
+			fmt.Println(c)
+		}
+	}
+	return 0
+}
+
+func completions(before []string, current string) []string {
+	if strings.HasPrefix(current, "-") {
+		return flagNames()
+	}
+
+	args, wantsValue := positionals(before)
+	if wantsValue {
+		return nil
+	}
+
+	if repo, _, ok := strings.Cut(current, "@"); ok {
+		return prefixed(repo+"@", knownTags(repo))
+	}
+
+	if len(args) == 0 {
+		names := make([]string, 0, len(subcommands))
+		for name := range subcommands {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return append(names, knownRepos()...)
+	}
+
+	if sub, ok := subcommands[args[0]]; ok {
+		switch {
+		case (args[0] == "get" || args[0] == "export") && len(args) == 1:
+			return knownRepos()
+		case args[0] == "watch" && len(args) >= 2 && (args[1] == "add" || args[1] == "remove"):
+			return knownRepos()
+		case len(args) == 1:
+			return sub
+		}
+		return nil
+	}
+
+	// owner/repo@version arguments can be followed by more of them
+	if strings.Contains(args[0], "@") {
+		return knownRepos()
+	}
+
+	if len(args) == 1 {
+		if from, _, ok := strings.Cut(current, ".."); ok {
+			return prefixed(from+"..", knownTags(args[0]))
+		}
+		return knownTags(args[0])
+	}
+
+	return nil
+}
+
+// positionals drops the flags and their values from words, reporting
+// whether the last one is a flag still waiting for its value.
+func positionals(words []string) ([]string, bool) {
+	args := []string{}
+
+	for i := 0; i < len(words); i++ {
+		w := words[i]
+		if !strings.HasPrefix(w, "-") {
+			args = append(args, w)
+			continue
+		}
+
+		name := strings.TrimLeft(w, "-")
+		if strings.Contains(name, "=") {
+			continue
+		}
+		if f := flag.Lookup(name); f != nil && !isBoolFlag(f) {
+			if i == len(words)-1 {
+				return args, true
+			}
+			i++
+		}
+	}
+
+	return args, false
+}
+
+func isBoolFlag(f *flag.Flag) bool {
+	b, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && b.IsBoolFlag()
+}
+
+func flagNames() []string {
+	names := []string{}
+	flag.VisitAll(func(f *flag.Flag) {
+		names = append(names, "--"+f.Name)
+	})
+	return names
+}
+
+func prefixed(prefix string, list []string) []string {
+	out := make([]string, len(list))
+	for i, s := range list {
+		out[i] = prefix + s
+	}
+	return out
+}
+
+// knownRepos lists the pinned repositories, the aliases and the browsing
+// history, most recently browsed first, the way the quick switcher does.
+func knownRepos() []string {
+	seen := map[string]bool{}
+	out := []string{}
+	add := func(repo string) {
+		if !seen[repo] {
+			seen[repo] = true
+			out = append(out, repo)
+		}
+	}
+
+	for _, p := range AppConfig.Pins {
+		add(p)
+	}
+	for _, p := range AppStore.Keys(store.BucketPins, "") {
+		add(p)
+	}
+
+	aliases := make([]string, 0, len(AppConfig.Aliases))
+	for name := range AppConfig.Aliases {
+		aliases = append(aliases, name)
+	}
+	sort.Strings(aliases)
+	for _, name := range aliases {
+		add(name)
+	}
+
+	type visit struct {
+		At time.Time `json:"at"`
+	}
+	recent := AppStore.Keys(store.BucketHistory, "")
+	visits := make(map[string]time.Time, len(recent))
+	for _, repo := range recent {
+		v := visit{}
+		if ok, err := AppStore.Get(store.BucketHistory, repo, &v); ok && err == nil {
+			visits[repo] = v.At
+		}
+	}
+	sort.SliceStable(recent, func(i, j int) bool { return visits[recent[i]].After(visits[recent[j]]) })
+	for _, repo := range recent {
+		add(repo)
+	}
+
+	return out
+}
+
+// knownTags lists the tags of arg's releases as they were last fetched,
+// newest first; nothing when they were never fetched.
+func knownTags(arg string) []string {
+	*offline = true
+
+	name, err := projectURL(arg)
+	if err != nil {
+		return nil
+	}
+	owner, repo, err := splitRepo(name)
+	if err != nil {
+		return nil
+	}
+
+	provider, err := newProvider()
+	if err != nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	list, err := provider.ListReleases(ctx, owner, repo)
+	if err != nil {
+		return nil
+	}
+
+	tags := make([]string, len(list))
+	for i, r := range list {
+		tags[i] = r.Tag
+	}
+	return tags
+}
@@ -0,0 +1,368 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Config struct {
+	DefaultOrg string `yaml:"default_org"`
+
+	// Proxy overrides HTTPS_PROXY for API requests.
+	Proxy string `yaml:"proxy"`
+	// CABundle is a PEM file trusted in addition to the system roots, for
+	// corporate proxies that re-sign TLS traffic.
+	CABundle string `yaml:"ca_bundle"`
+	// InsecureSkipVerify disables TLS verification entirely. Last resort.
+	InsecureSkipVerify bool `yaml:"tls_insecure_skip_verify"`
+
+	// HTTPTimeout bounds each API request, e.g. "30s".
+	HTTPTimeout time.Duration `yaml:"http_timeout"`
+	// UserAgent is sent ahead of brows's own product token.
+	UserAgent string `yaml:"user_agent"`
+
+	// Provider is where releases come from: "github" (the default),
+	// "gitlab", "gitea" (or "forgejo") or "bitbucket".
+	Provider string `yaml:"provider"`
+	// GitLabBaseURL is a self-hosted GitLab; gitlab.com by default.
+	GitLabBaseURL string `yaml:"gitlab_base_url"`
+	// GitLabToken is a GitLab personal access token, used when
+	// GITLAB_TOKEN isn't set.
+	GitLabToken string `yaml:"gitlab_token"`
+
+	// GiteaBaseURL is a Gitea or Forgejo instance; codeberg.org by
+	// default.
+	GiteaBaseURL string `yaml:"gitea_base_url"`
+	// GiteaToken is a Gitea access token, used when GITEA_TOKEN isn't set.
+	GiteaToken string `yaml:"gitea_token"`
+
+	// BitbucketToken is a Bitbucket Cloud access token, or an app password
+	// when BitbucketUsername is set too; BITBUCKET_TOKEN and
+	// BITBUCKET_USERNAME take precedence.
+	BitbucketUsername string `yaml:"bitbucket_username"`
+	BitbucketToken    string `yaml:"bitbucket_token"`
+
+	// GitHubBaseURL points brows at a GitHub Enterprise Server, e.g.
+	// "https://github.example.com/".
+	GitHubBaseURL string `yaml:"github_base_url"`
+
+	// Token is a GitHub token stored directly in the config file.
+	Token string `yaml:"token"`
+	// TokenSources lists the places to look for a token, in order.
+	TokenSources []string `yaml:"token_sources"`
+	// OAuthClientID is the OAuth app "brows auth login" logs in as, for
+	// builds without one and Enterprise Servers.
+	OAuthClientID string `yaml:"oauth_client_id"`
+	// TokenCommand is a shell command that prints a token, for the
+	// "command" token source: a password manager's CLI, say.
+	TokenCommand string `yaml:"token_command"`
+
+	// NoGraphQL lists releases through the REST API even when the token
+	// could use GraphQL, trading speed for responses the disk cache can
+	// revalidate for free.
+	NoGraphQL bool `yaml:"no_graphql"`
+
+	// RateReserve is how many API requests background fetching leaves
+	// untouched for interactive use.
+	RateReserve int `yaml:"rate_reserve"`
+
+	// Theme is "dark", "light", or "auto" to detect the terminal's
+	// background.
+	Theme string `yaml:"theme"`
+	// Style overrides Theme with a glamour style name or the path of a
+	// JSON stylesheet.
+	Style string `yaml:"style"`
+
+	// ListLayout starts the viewer with the releases listed beside the
+	// notes instead of on the timeline.
+	ListLayout bool `yaml:"list_layout"`
+
+	// ImageProtocol is how images in notes are previewed: "kitty",
+	// "iterm2", "sixel" (through img2sixel), "none", or "auto" to detect
+	// kitty and iTerm2.
+	ImageProtocol string `yaml:"image_protocol"`
+
+	// NoHyperlinks disables terminal hyperlinks in release notes.
+	NoHyperlinks bool `yaml:"no_hyperlinks"`
+
+	// ScrollStep is how many lines a single scroll key moves.
+	ScrollStep int `yaml:"scroll_step"`
+	// ScrollPastEnd allows scrolling the last line to the top.
+	ScrollPastEnd bool `yaml:"scroll_past_end"`
+
+	// MouseWheelLines is how many lines one wheel notch scrolls.
+	MouseWheelLines int `yaml:"mouse_wheel_lines"`
+	// NoMouse leaves the mouse to the terminal, so native text selection
+	// works.
+	NoMouse bool `yaml:"no_mouse"`
+
+	// JumpToBreaking scrolls each release to its breaking changes heading.
+	JumpToBreaking bool `yaml:"jump_to_breaking"`
+
+	// StaleMajors and StaleMonths set when to warn that the current
+	// version has fallen far behind; -1 turns either check off.
+	StaleMajors int `yaml:"stale_majors"`
+	StaleMonths int `yaml:"stale_months"`
+
+	// DownloadDir is where assets are saved; the current directory by
+	// default.
+	DownloadDir string `yaml:"download_dir"`
+	// CosignKey verifies asset signatures with this public key instead of
+	// keyless certificates. CosignIdentity and CosignIssuer override the
+	// regexps keyless certificates must match.
+	CosignKey      string `yaml:"cosign_key"`
+	CosignIdentity string `yaml:"cosign_identity"`
+	CosignIssuer   string `yaml:"cosign_issuer"`
+
+	// Pins are "owner/repo" entries listed first in the quick switcher.
+	Pins []string `yaml:"pins"`
+	// Aliases map short names to "owner/repo", on the command line and in
+	// the quick switcher.
+	Aliases map[string]string `yaml:"aliases"`
+
+	// Repos override settings for single repositories, by "owner/repo".
+	Repos map[string]RepoConfig `yaml:"repos"`
+
+	// Profiles are named sets of settings, chosen with --profile (or
+	// BROWS_PROFILE), that override the ones above.
+	Profiles map[string]yaml.Node `yaml:"profiles"`
+}
+
+// RepoConfig is what a repository's entry under repos can override.
+type RepoConfig struct {
+	// TagPrefix picks out one component's releases in a repository that
+	// tags several, like "cli-" for cli-v1.2.3.
+	TagPrefix string `yaml:"tag_prefix"`
+	// Provider and BaseURL say where the repository is hosted, in place of
+	// the provider and github_base_url, gitlab_base_url or gitea_base_url
+	// keys.
+	Provider string `yaml:"provider"`
+	BaseURL  string `yaml:"base_url"`
+	// StableOnly hides its prereleases and drafts, like --stable-only.
+	StableOnly bool `yaml:"stable_only"`
+}
+
+const defaultHTTPTimeout = 30 * time.Second
+
+var AppConfig *Config
+
+// legacyConfigPath is where the config lived before brows followed the
+// XDG base directory spec, relative to the home directory.
+const legacyConfigPath = ".config/brows.yml"
+
+// configFile is brows/config.yml in $XDG_CONFIG_HOME (~/.config by
+// default), or the legacy ~/.config/brows.yml when only that one exists.
+func configFile() string {
+	home, _ := os.UserHomeDir()
+
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" || !filepath.IsAbs(dir) {
+		dir = filepath.Join(home, ".config")
+	}
+	path := filepath.Join(dir, "brows", "config.yml")
+
+	if _, err := os.Stat(path); err != nil {
+		legacy := filepath.Join(home, legacyConfigPath)
+		if _, err := os.Stat(legacy); err == nil {
+			return legacy
+		}
+	}
+
+	return path
+}
+
+func ReadConfig() {
+	AppConfig = &Config{}
+	path := configFile()
+
+	f, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	decoder := yaml.NewDecoder(f)
+	decoder.Decode(&AppConfig)
+}
+
+// useProfile applies the settings of the profile --profile or
+// BROWS_PROFILE names over the rest of the config.
+func useProfile() error {
+	name := *profileName
+	if name == "" {
+		name = os.Getenv("BROWS_PROFILE")
+	}
+	if name == "" {
+		return nil
+	}
+
+	node, ok := AppConfig.Profiles[name]
+	if !ok {
+		return fmt.Errorf("no profile %q in %s", name, configFile())
+	}
+	if err := node.Decode(AppConfig); err != nil {
+		return fmt.Errorf("profile %q: %v", name, err)
+	}
+
+	slog.Debug("profile selected", "profile", name)
+	return nil
+}
+
+// repoConfig is the repos entry for owner/repo, if there is one.
+func repoConfig(owner, repo string) RepoConfig {
+	return AppConfig.Repos[owner+"/"+repo]
+}
+
+// forRepo is the config with owner/repo's overrides of where it's hosted
+// applied.
+func forRepo(owner, repo string) *Config {
+	rc := repoConfig(owner, repo)
+	if rc.Provider == "" && rc.BaseURL == "" {
+		return AppConfig
+	}
+
+	cfg := *AppConfig
+	if rc.Provider != "" {
+		cfg.Provider = rc.Provider
+	}
+	if rc.BaseURL != "" {
+		switch cfg.Provider {
+		case "gitlab":
+			cfg.GitLabBaseURL = rc.BaseURL
+		case "gitea", "forgejo":
+			cfg.GiteaBaseURL = rc.BaseURL
+		default:
+			cfg.GitHubBaseURL = rc.BaseURL
+		}
+	}
+	return &cfg
+}
+
+// removeConfigKey deletes a top-level key from the config file, leaving
+// the rest of the file (including comments) as it was.
+func removeConfigKey(key string) (bool, error) {
+	path := configFile()
+
+	in, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(in, &doc); err != nil {
+		return false, err
+	}
+
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return false, nil
+	}
+
+	mapping := doc.Content[0]
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+
+			out, err := yaml.Marshal(&doc)
+			if err != nil {
+				return false, err
+			}
+			return true, os.WriteFile(path, out, 0600)
+		}
+	}
+
+	return false, nil
+}
+
+// configScaffold is what brows config init writes: the commonest settings,
+// commented out.
+const configScaffold = `# brows configuration. Every key is optional; see the README for them all.
+
+# The organization "brows repo" means.
+# default_org: charmbracelet
+
+# Where releases come from: github (the default), gitlab, gitea (or
+# forgejo) or bitbucket, and where a self-hosted instance is.
+# provider: github
+# github_base_url: https://github.example.com/
+# gitlab_base_url: https://gitlab.example.com/
+# gitea_base_url: https://codeberg.org/
+
+# Where the GitHub token comes from, in order, and a command that prints
+# one (here 1Password's CLI) for the "command" source.
+# token_sources: [GITHUB_TOKEN, command, gh]
+# token_command: op read op://Private/GitHub/token
+
+# dark, light, or auto to detect the terminal's background.
+# theme: auto
+
+# Start with the releases listed beside the notes instead of on the timeline.
+# list_layout: true
+
+# Listed first in the quick switcher (ctrl+p), and short names for repos.
+# pins:
+#   - charmbracelet/bubbletea
+# aliases:
+#   tea: charmbracelet/bubbletea
+
+# Settings for single repositories.
+# repos:
+#   kubernetes/kubernetes:
+#     stable_only: true
+#   aws/aws-sdk-go-v2:
+#     tag_prefix: service/s3/
+#   platform/tools:
+#     provider: gitlab
+#     base_url: https://gitlab.example.com/
+
+# Named sets of settings that override the ones above, chosen with
+# --profile work or BROWS_PROFILE=work.
+# profiles:
+#   work:
+#     github_base_url: https://github.example.com/
+#     default_org: platform
+`
+
+func runConfig(args []string) int {
+	switch {
+	case len(args) == 1 && args[0] == "init":
+		return runConfigInit()
+	case len(args) == 1 && args[0] == "path":
+		fmt.Println(configFile())
+		return 0
+	}
+
+	fmt.Println("Usage:")
+	fmt.Println("  brows config init")
+	fmt.Println("  brows config path")
+	return 1
+}
+
+// runConfigInit writes a commented config file to start from, unless there
+// already is one.
+func runConfigInit() int {
+	path := configFile()
+
+	if _, err := os.Stat(path); err == nil {
+		fmt.Fprintf(os.Stderr, "%s already exists\n", path)
+		return 1
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if err := os.WriteFile(path, []byte(configScaffold), 0600); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	fmt.Printf("Wrote %s\n", path)
+	return 0
+}
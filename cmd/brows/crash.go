@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const crashDir = "$HOME/.local/state/brows"
+
+// crashGuard wraps the root model so that a panic anywhere in Update, View
+// or a command quits the program cleanly (letting Bubble Tea restore the
+// terminal) and leaves a crash report behind instead of a raw-mode shell.
+type crashGuard struct {
+	model   tea.Model
+	program *tea.Program
+
+	mu      sync.Mutex
+	lastMsg string
+	report  string
+}
+
+type panicMsg struct {
+	value interface{}
+	stack []byte
+}
+
+func newCrashGuard(m tea.Model) *crashGuard {
+	return &crashGuard{model: m}
+}
+
+func (g *crashGuard) Init() tea.Cmd {
+	return g.guardCmd(g.model.Init())
+}
+
+func (g *crashGuard) Update(msg tea.Msg) (model tea.Model, cmd tea.Cmd) {
+	if p, ok := msg.(panicMsg); ok {
+		g.crash(p.value, p.stack)
+		return g, tea.Quit
+	}
+
+	g.mu.Lock()
+	g.lastMsg = fmt.Sprintf("%T %+v", msg, msg)
+	g.mu.Unlock()
+
+	defer func() {
+		if r := recover(); r != nil {
+			g.crash(r, debug.Stack())
+			model, cmd = g, tea.Quit
+		}
+	}()
+
+	g.model, cmd = g.model.Update(msg)
+	return g, g.guardCmd(cmd)
+}
+
+func (g *crashGuard) View() (view string) {
+	defer func() {
+		if r := recover(); r != nil {
+			g.crash(r, debug.Stack())
+			if g.program != nil {
+				go g.program.Quit()
+			}
+			view = ""
+		}
+	}()
+
+	return g.model.View()
+}
+
+// guardCmd turns a panic inside cmd into a panicMsg, which is delivered
+// back to Update on the program's goroutine. Batches are unpacked so their
+// members are guarded too.
+func (g *crashGuard) guardCmd(cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+
+	return func() (msg tea.Msg) {
+		defer func() {
+			if r := recover(); r != nil {
+				msg = panicMsg{r, debug.Stack()}
+			}
+		}()
+
+		msg = cmd()
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			guarded := make(tea.BatchMsg, len(batch))
+			for i, c := range batch {
+				guarded[i] = g.guardCmd(c)
+			}
+			msg = guarded
+		}
+
+		return msg
+	}
+}
+
+// crash writes a report for the first panic seen; later ones are ignored
+// since the program is already on its way out.
+func (g *crashGuard) crash(value interface{}, stack []byte) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.report != "" {
+		return
+	}
+
+	dir := os.ExpandEnv(crashDir)
+	path := filepath.Join(dir, fmt.Sprintf("crash-%s.log", time.Now().Format("20060102-150405")))
+
+	content := fmt.Sprintf("brows %s crashed at %s\n\npanic: %v\n\nlast message: %s\n\n%s",
+		version, time.Now().Format(time.RFC3339), value, g.lastMsg, stack)
+
+	if err := os.MkdirAll(dir, 0755); err == nil {
+		if err := os.WriteFile(path, []byte(content), 0644); err == nil {
+			g.report = path
+			return
+		}
+	}
+
+	// couldn't write the file; keep the report so main can print it
+	g.report = "-"
+	g.lastMsg = content
+}
+
+// Report returns a user-facing description of the crash, or "" if the
+// program didn't crash.
+func (g *crashGuard) Report() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	switch g.report {
+	case "":
+		return ""
+	case "-":
+		return g.lastMsg
+	default:
+		return fmt.Sprintf("brows crashed; a crash report was written to %s", g.report)
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rubysolo/brows/pkg/ui"
+)
+
+// runDashboard lists every dependency found in the current directory's
+// manifests with how far behind it is, and opens the release viewer for
+// the one picked.
+func runDashboard() int {
+	deps, err := loadDependencies(true)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	provider, err := newProvider()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	fmt.Fprintf(os.Stderr, "checking %d dependencies...\n", len(deps))
+	results, err := checkDependencies(provider, deps)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	names := map[string]string{}
+	for _, d := range deps {
+		names[d.Repo] = d.Name
+	}
+
+	rows := make([]ui.DashboardRow, len(results))
+	for i, r := range results {
+		name := names[r.Repo]
+		if name == "" {
+			name = r.Repo
+		}
+		rows[i] = ui.DashboardRow{Name: name, Repo: r.Repo, Source: r.Source, Behind: r.Behind, Error: r.Error}
+	}
+
+	opts, err := uiOptions(provider)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	return runTUI(ui.NewDashboard(rows, func(row ui.DashboardRow) (ui.Model, error) {
+		owner, repo, err := splitRepo(row.Repo)
+		if err != nil {
+			return ui.Model{}, err
+		}
+		version := row.Current
+		if version == "" {
+			version = "0.0.0"
+		}
+		return ui.New(provider, owner, repo, version, opts)
+	}))
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rubysolo/brows/pkg/releases"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+// runExport writes the notes of the releases after a version, up to --to
+// or the latest, to -o or stdout, as markdown, HTML or JSON: the notes of
+// an upgrade in one file, without the TUI.
+func runExport(args []string) int {
+	if len(args) < 1 || len(args) > 2 {
+		fmt.Fprintln(os.Stderr, "Usage: brows export organization/repo [version | from..to] [--to version] [--format md|html|json] [-o file]")
+		return 1
+	}
+
+	targets, err := browseTargets(args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	t := targets[0]
+	if *exportTo != "" {
+		t.until = *exportTo
+	}
+
+	format := *exportFormat
+	if format == "" {
+		format = formatFor(*exportOutput)
+	}
+
+	provider, err := providerFor(t.owner, t.repo)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if repoConfig(t.owner, t.repo).StableOnly {
+		*stableOnly = true
+	}
+
+	list, err := newerReleases(provider, t.owner, t.repo, t.version, t.until)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	var out []byte
+	switch format {
+	case "md", "markdown":
+		out = []byte(releases.Aggregate(list))
+	case "json":
+		out, err = json.MarshalIndent(releasesJSON(list), "", "  ")
+		out = append(out, '\n')
+	case "html":
+		out, err = notesHTML(t.owner+"/"+t.repo, list)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown format %q (expected md, html or json)\n", format)
+		return 1
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if *exportOutput == "" {
+		os.Stdout.Write(out)
+		return 0
+	}
+
+	if err := os.WriteFile(*exportOutput, out, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	fmt.Printf("Wrote the notes of %d releases of %s/%s to %s\n", len(list), t.owner, t.repo, *exportOutput)
+	return 0
+}
+
+// formatFor picks the export format from the output file's extension,
+// markdown unless it says otherwise.
+func formatFor(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".html", ".htm":
+		return "html"
+	case ".json":
+		return "json"
+	}
+	return "md"
+}
+
+// notesHTML renders the aggregated notes of list as a standalone HTML
+// page titled after repo. Raw HTML in the notes is left out.
+func notesHTML(repo string, list []releases.Release) ([]byte, error) {
+	var body bytes.Buffer
+	md := goldmark.New(goldmark.WithExtensions(extension.GFM))
+	if err := md.Convert([]byte(releases.Aggregate(list)), &body); err != nil {
+		return nil, err
+	}
+
+	title := html.EscapeString(repo + " release notes")
+	if len(list) > 0 {
+		title = html.EscapeString(fmt.Sprintf("%s release notes, %s to %s", repo, list[0].Tag, list[len(list)-1].Tag))
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n</head>\n<body>\n", title)
+	b.Write(body.Bytes())
+	b.WriteString("</body>\n</html>\n")
+	return b.Bytes(), nil
+}
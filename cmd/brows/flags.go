@@ -0,0 +1,105 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+var (
+	verbose       = flag.Bool("verbose", false, "write structured debug logs to "+logPath)
+	starred       = flag.Bool("starred", false, "list your starred repositories with their latest releases, and browse any of them")
+	all           = flag.Bool("all", false, "list every dependency in the current directory's manifests with how far behind it is, and browse any of them")
+	check         = flag.Bool("check", false, "report available updates for each owner/repo[@version] argument, without the TUI")
+	jsonOutput    = flag.Bool("json", false, "print machine-readable JSON instead of a table or TUI")
+	plain         = flag.Bool("plain", false, "print the release notes instead of starting the TUI (the default when stdout isn't a terminal)")
+	raw           = flag.Bool("raw", false, "like --plain, but print the notes as unrendered markdown")
+	aggregate     = flag.Bool("aggregate", false, "open with the notes of every release after your version in one document")
+	fromGomod     = flag.Bool("from-gomod", false, "browse a dependency from ./go.mod at its required version: brows --from-gomod [module]")
+	fromGemfile   = flag.Bool("from-gemfile", false, "browse a gem from ./Gemfile.lock at its locked version: brows --from-gemfile [gem]")
+	fromNpm       = flag.String("from-npm", "", "browse an npm package at the version in package-lock.json (or node_modules)")
+	fromCargo     = flag.String("from-cargo", "", "browse a crate at the version pinned in Cargo.lock")
+	fromPypi      = flag.String("from-pypi", "", "browse a Python package at the version in uv.lock, poetry.lock or requirements.txt")
+	detect        = flag.Bool("detect", false, "start from the tag git describe finds in the current directory, even when it isn't a checkout of the repository")
+	noCache       = flag.Bool("no-cache", false, "neither read nor write the response cache")
+	refresh       = flag.Bool("refresh", false, "fetch everything afresh, replacing what's cached")
+	offline       = flag.Bool("offline", false, "don't touch the network; browse only what's in the response cache")
+	stableOnly    = flag.Bool("stable-only", false, "hide prereleases and drafts (p toggles them in the TUI)")
+	themeName     = flag.String("theme", "", "render notes for a dark or light terminal background, or auto to detect it (the default)")
+	styleName     = flag.String("style", "", "glamour style to render notes in: a built-in one (dark, light, notty, ascii, dracula, pink) or a JSON stylesheet")
+	noMouse       = flag.Bool("no-mouse", false, "don't capture the mouse, so the terminal's text selection works")
+	workspaceFile = flag.String("workspace", "", "dependency list for status and outdated (default: deps.yml or go.mod in the current directory)")
+	exportTo      = flag.String("to", "", "the last version brows export includes (default: the latest)")
+	exportFormat  = flag.String("format", "", "what brows export writes: md, html or json (default: from -o's extension, or md)")
+	exportOutput  = flag.String("o", "", "the file brows export writes to (default: stdout)")
+	unverified    = flag.Bool("allow-unverified", false, "save downloaded assets whose checksum or signature checks couldn't run, or that have none")
+	force         = flag.Bool("force", false, "let downloads replace a file already in the download directory")
+	short         = flag.Bool("short", false, "print a one-line summary for status bars and prompts")
+	providerName  = flag.String("provider", "", "where to fetch releases from: github (default), gitlab, gitea, forgejo or bitbucket")
+	baseURL       = flag.String("base-url", "", "GitHub Enterprise Server URL, e.g. https://github.example.com/")
+	profileName   = flag.String("profile", "", "use the settings of this profile from the config file's profiles")
+	tokenSource   = flag.String("token-source", "", "only read the GitHub token from this source, e.g. GH_TOKEN")
+)
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage:")
+	fmt.Fprintln(os.Stderr, "  brows")
+	fmt.Fprintln(os.Stderr, "  brows [flags] organization/repo [version | from..to]")
+	fmt.Fprintln(os.Stderr, "  brows [flags] organization/repo[@version]...")
+	fmt.Fprintln(os.Stderr, "  brows [flags] https://gitlab.com/group/project [version]")
+	fmt.Fprintln(os.Stderr, "  brows [flags] https://codeberg.org/owner/repo [version]")
+	fmt.Fprintln(os.Stderr, "  brows [flags] git@github.com:organization/repo.git [version]")
+	fmt.Fprintln(os.Stderr, "  brows --from-gomod [module]")
+	fmt.Fprintln(os.Stderr, "  brows --from-gemfile [gem]")
+	fmt.Fprintln(os.Stderr, "  brows --from-npm package")
+	fmt.Fprintln(os.Stderr, "  brows --from-cargo crate")
+	fmt.Fprintln(os.Stderr, "  brows --from-pypi package")
+	fmt.Fprintln(os.Stderr, "  brows --all")
+	fmt.Fprintln(os.Stderr, "  brows --starred")
+	fmt.Fprintln(os.Stderr, "  brows --check [--json] organization/repo[@version]...")
+	fmt.Fprintln(os.Stderr, "  brows status [--workspace deps.yml] [--short | --json]")
+	fmt.Fprintln(os.Stderr, "  brows outdated [--workspace deps.yml] [--json]")
+	fmt.Fprintln(os.Stderr, "  brows get organization/repo[@tag] [--force] [--allow-unverified]")
+	fmt.Fprintln(os.Stderr, "  brows export organization/repo version [--to version] [--format md|html|json] [-o file]")
+	fmt.Fprintln(os.Stderr, "  brows watch add organization/repo[@version] | remove | list | check")
+	fmt.Fprintln(os.Stderr, "  brows auth status")
+	fmt.Fprintln(os.Stderr, "  brows logout")
+	fmt.Fprintln(os.Stderr, "  brows state export [file] | import file")
+	fmt.Fprintln(os.Stderr, "  brows config init | path")
+	fmt.Fprintln(os.Stderr, "  brows completion bash|zsh|fish")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Flags:")
+	flag.PrintDefaults()
+}
+
+// flagSet reports whether the flag name was given on the command line,
+// rather than left at its default.
+func flagSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+// parseArgs parses flags wherever they appear on the command line, so
+// `brows org/repo 1.2.3 --verbose` works as well as the flag-first form,
+// and returns the remaining positional arguments.
+func parseArgs(args []string) []string {
+	flag.Usage = usage
+
+	positional := []string{}
+	for {
+		flag.CommandLine.Parse(args)
+		args = flag.Args()
+
+		if len(args) == 0 {
+			return positional
+		}
+
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const rubygemsAPI = "https://rubygems.org/api/v1/gems/"
+
+// readGemfileLock lists the gems a Gemfile.lock's DEPENDENCIES section
+// names, at their locked versions. Gems from a GitHub GIT source already
+// know their repository; the rest are left for gemRepository.
+func readGemfileLock(path string) ([]dependency, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	locked := map[string]string{}
+	repos := map[string]string{}
+	direct := []string{}
+
+	section, remote := "", ""
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+
+		if !strings.HasPrefix(line, " ") {
+			section, remote = line, ""
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		switch section {
+		case "GEM", "GIT", "PATH":
+			if r, ok := strings.CutPrefix(trimmed, "remote: "); ok {
+				remote = r
+				continue
+			}
+
+			// specs are indented four spaces; their own requirements six
+			if strings.HasPrefix(line, "    ") && !strings.HasPrefix(line, "     ") {
+				name, version, ok := strings.Cut(trimmed, " (")
+				if !ok {
+					continue
+				}
+				version = strings.TrimSuffix(version, ")")
+				// drop platform suffixes like -x86_64-linux
+				version, _, _ = strings.Cut(version, "-")
+				locked[name] = version
+
+				if section == "GIT" {
+					if repo, ok := githubRepoURL(remote); ok {
+						repos[name] = repo
+					}
+				}
+			}
+
+		case "DEPENDENCIES":
+			name, _, _ := strings.Cut(trimmed, " ")
+			direct = append(direct, strings.TrimSuffix(name, "!"))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Strings(direct)
+	deps := []dependency{}
+	for _, name := range direct {
+		version, ok := locked[name]
+		if !ok {
+			continue
+		}
+		deps = append(deps, dependency{Name: name, Repo: repos[name], Version: version, Source: filepath.Base(path)})
+	}
+
+	return deps, nil
+}
+
+// readGemfile is readGemfileLock with every gem's repository looked up,
+// skipping gems that aren't developed on GitHub.
+func readGemfile(path string) ([]dependency, error) {
+	deps, err := readGemfileLock(path)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := newHTTPClient(AppConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return resolveRepos(deps, func(d dependency) (string, error) {
+		return gemRepository(client, d.Name)
+	}), nil
+}
+
+// gemRepository asks rubygems.org where the gem name is developed, trying
+// the links its metadata lists from most to least specific.
+func gemRepository(client *http.Client, name string) (string, error) {
+	req, err := http.NewRequestWithContext(context.Background(), "GET", rubygemsAPI+url.PathEscape(name)+".json", nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("rubygems.org: %s: %s", name, resp.Status)
+	}
+
+	meta := struct {
+		SourceCode string `json:"source_code_uri"`
+		Homepage   string `json:"homepage_uri"`
+		Changelog  string `json:"changelog_uri"`
+		BugTracker string `json:"bug_tracker_uri"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&meta); err != nil {
+		return "", fmt.Errorf("rubygems.org: %s: %v", name, err)
+	}
+
+	if repo, ok := githubRepoIn(meta.SourceCode, meta.Homepage, meta.Changelog, meta.BugTracker); ok {
+		return repo, nil
+	}
+
+	return "", fmt.Errorf("rubygems.org doesn't link %s to a GitHub repository", name)
+}
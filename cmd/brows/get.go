@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/signal"
+	"runtime"
+	"strings"
+
+	"github.com/rubysolo/brows/pkg/releases"
+)
+
+// runGet downloads the asset of owner/repo[@tag] built for this machine,
+// the latest stable release if no tag is given. When several assets match
+// equally well it asks which one to take. It won't replace a file that's
+// already there without --force, nor save an asset whose checks couldn't
+// run without --allow-unverified.
+func runGet(args []string) int {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "Usage: brows get owner/repo[@tag]")
+		return 1
+	}
+
+	arg, err := projectURL(args[0])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	name, tag, _ := strings.Cut(arg, "@")
+	owner, repo, err := splitRepo(name)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	provider, err := newProvider()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	// interrupting a download still removes its partial file
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	list, err := releases.Fetch(ctx, releases.Ref{Owner: owner, Repo: repo}, releases.WithProvider(provider))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if tag == "" {
+		behind, _ := releases.Compare("0.0.0", list)
+		tag = behind.Latest
+	}
+
+	var release releases.Release
+	found := false
+	for _, r := range list {
+		if r.Tag == tag {
+			release, found = r, true
+		}
+	}
+	if !found {
+		fmt.Fprintf(os.Stderr, "%s/%s has no release %q\n", owner, repo, tag)
+		return 1
+	}
+
+	matches, ok := releases.MatchPlatform(release.Assets, runtime.GOOS, runtime.GOARCH)
+	if len(matches) == 0 {
+		fmt.Fprintf(os.Stderr, "No asset of %s %s matches %s/%s.\n", name, tag, runtime.GOOS, runtime.GOARCH)
+		return 1
+	}
+
+	asset := matches[0]
+	if !ok {
+		asset, err = chooseAsset(matches)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+	}
+
+	client, err := newHTTPClient(AppConfig)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	fmt.Printf("Downloading %s from %s/%s %s...\n", asset.Name, owner, repo, tag)
+	path, checks, err := newDownloader(client, provider.WebURL()).Download(ctx, owner, repo, release, asset, os.ExpandEnv(AppConfig.DownloadDir))
+	for _, c := range checks {
+		fmt.Println(" ", c)
+	}
+
+	var verr *releases.VerificationError
+	if errors.As(err, &verr) {
+		fmt.Fprintln(os.Stderr, verr)
+		if verr.Check.Err == nil {
+			fmt.Fprintln(os.Stderr, "Pass --allow-unverified to save it anyway.")
+		}
+		return 2
+	} else if errors.Is(err, fs.ErrExist) {
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, "Pass --force to replace it.")
+		return 1
+	} else if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	fmt.Println("Saved", path)
+
+	return 0
+}
+
+// chooseAsset asks which of the equally good matches to download.
+func chooseAsset(matches []releases.Asset) (releases.Asset, error) {
+	names := make([]string, len(matches))
+	for i, a := range matches {
+		names[i] = a.Name
+	}
+
+	i, err := pick(fmt.Sprintf("Several assets match %s/%s:", runtime.GOOS, runtime.GOARCH), names)
+	if err != nil {
+		return releases.Asset{}, err
+	}
+
+	return matches[i], nil
+}
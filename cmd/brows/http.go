@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/google/go-github/v48/github"
+	"golang.org/x/oauth2"
+)
+
+// newHTTPClient builds the client every API call goes through. It honors
+// HTTPS_PROXY/NO_PROXY (or the proxy config key), trusts the configured CA
+// bundle in addition to the system roots, only skips TLS verification when
+// the config explicitly asks for it, identifies itself with userAgent,
+// retries requests refused by secondary rate limits, and caches responses
+// on disk unless --no-cache is given, falling back on them when the network
+// is unreachable or the rate limit is spent.
+func newHTTPClient(cfg *Config) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+
+	if cfg.Proxy != "" {
+		proxyURL, err := url.Parse(cfg.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy %q: %v", cfg.Proxy, err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsConfig := &tls.Config{}
+
+	if cfg.CABundle != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+
+		pem, err := os.ReadFile(os.ExpandEnv(cfg.CABundle))
+		if err != nil {
+			return nil, fmt.Errorf("reading ca_bundle: %v", err)
+		}
+
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in ca_bundle %s", cfg.CABundle)
+		}
+
+		tlsConfig.RootCAs = pool
+		slog.Debug("custom CA bundle loaded", "path", cfg.CABundle)
+	}
+
+	if cfg.InsecureSkipVerify {
+		tlsConfig.InsecureSkipVerify = true
+		slog.Warn("TLS certificate verification disabled by config")
+	}
+
+	transport.TLSClientConfig = tlsConfig
+
+	timeout := cfg.HTTPTimeout
+	if timeout <= 0 {
+		timeout = defaultHTTPTimeout
+	}
+
+	var base http.RoundTripper = retryTransport{transport}
+	if !*noCache || *offline {
+		base = cacheTransport{st: AppStore, base: base, refresh: *refresh, offline: *offline}
+	}
+
+	return &http.Client{
+		Transport: userAgentTransport{userAgent(cfg), base},
+		Timeout:   timeout,
+	}, nil
+}
+
+func userAgent(cfg *Config) string {
+	ua := fmt.Sprintf("brows/%s (+https://github.com/rubysolo/brows)", version)
+	if cfg.UserAgent != "" {
+		ua = cfg.UserAgent + " " + ua
+	}
+	return ua
+}
+
+type userAgentTransport struct {
+	userAgent string
+	base      http.RoundTripper
+}
+
+func (t userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.userAgent)
+	return t.base.RoundTrip(req)
+}
+
+// newGitHubClient returns an API client authenticated with token, or an
+// anonymous one if token is empty.
+func newGitHubClient(cfg *Config, token string) (*github.Client, error) {
+	httpClient, err := newHTTPClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if token != "" {
+		ctx := context.WithValue(context.Background(), oauth2.HTTPClient, httpClient)
+		ts := oauth2.StaticTokenSource(
+			&oauth2.Token{AccessToken: token},
+		)
+		tc := oauth2.NewClient(ctx, ts)
+		tc.Timeout = httpClient.Timeout
+		httpClient = tc
+	}
+
+	client := github.NewClient(httpClient)
+	if base := githubBaseURL(cfg); base != "" {
+		// NewEnterpriseClient adds the /api/v3/ and /api/uploads/ paths
+		client, err = github.NewEnterpriseClient(base, base, httpClient)
+		if err != nil {
+			return nil, fmt.Errorf("invalid GitHub base URL %q: %v", base, err)
+		}
+		slog.Debug("github enterprise server", "base_url", client.BaseURL.String())
+	}
+	client.UserAgent = userAgent(cfg)
+
+	return client, nil
+}
+
+// githubBaseURL is the GitHub Enterprise Server to talk to, if any:
+// --base-url, or else github_base_url from the config.
+func githubBaseURL(cfg *Config) string {
+	if *baseURL != "" {
+		return *baseURL
+	}
+	return cfg.GitHubBaseURL
+}
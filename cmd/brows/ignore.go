@@ -0,0 +1,308 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/masterminds/semver"
+	"github.com/rubysolo/brows/pkg/releases"
+	"gopkg.in/yaml.v3"
+)
+
+// updatePolicy is what a project's Renovate or Dependabot config says
+// about updating one dependency.
+type updatePolicy struct {
+	// skip ignores the dependency altogether.
+	skip bool
+
+	// allowed versions must satisfy every constraint or pattern; versions
+	// matching any ignored constraint are excluded.
+	allowed  []*semver.Constraints
+	patterns []versionPattern
+	ignored  []*semver.Constraints
+
+	ignoreMajor, ignoreMinor, ignorePatch bool
+}
+
+// versionPattern is a Renovate regex allowedVersions value.
+type versionPattern struct {
+	re     *regexp.Regexp
+	negate bool
+}
+
+func (vp versionPattern) allows(v string) bool {
+	return vp.re.MatchString(v) != vp.negate
+}
+
+// allows reports whether v is an update the project wants to hear about,
+// coming from current.
+func (p updatePolicy) allows(current, v *semver.Version) bool {
+	for _, c := range p.allowed {
+		if !c.Check(v) {
+			return false
+		}
+	}
+	for _, vp := range p.patterns {
+		if !vp.allows(v.Original()) {
+			return false
+		}
+	}
+	for _, c := range p.ignored {
+		if c.Check(v) {
+			return false
+		}
+	}
+
+	switch {
+	case v.Major() != current.Major():
+		return !p.ignoreMajor
+	case v.Minor() != current.Minor():
+		return !p.ignoreMinor
+	default:
+		return !p.ignorePatch
+	}
+}
+
+// filter drops the releases p doesn't allow updating to from current.
+func (p updatePolicy) filter(current string, list []releases.Release) []releases.Release {
+	cur, err := semver.NewVersion(current)
+	if err != nil {
+		return list
+	}
+
+	out := []releases.Release{}
+	for _, r := range list {
+		v, err := semver.NewVersion(r.Tag)
+		if err != nil || !v.GreaterThan(cur) || p.allows(cur, v) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// updateRules are the ignore rules read from a project's Renovate and
+// Dependabot configs.
+type updateRules struct {
+	rules []updateRule
+}
+
+type updateRule struct {
+	matches func(name string) bool
+	apply   func(p *updatePolicy)
+}
+
+// policy combines every rule that applies to the dependency named name.
+func (r updateRules) policy(name string) updatePolicy {
+	p := updatePolicy{}
+	for _, rule := range r.rules {
+		if rule.matches(name) {
+			rule.apply(&p)
+		}
+	}
+	return p
+}
+
+var renovateFiles = []string{"renovate.json", ".github/renovate.json", ".renovaterc.json", ".renovaterc"}
+
+var dependabotFiles = []string{".github/dependabot.yml", ".github/dependabot.yaml"}
+
+// readUpdateRules loads the Renovate and Dependabot configs in the
+// current directory, if there are any.
+func readUpdateRules() (updateRules, error) {
+	rules := updateRules{}
+
+	for _, name := range renovateFiles {
+		in, err := os.ReadFile(name)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return rules, err
+		}
+
+		if err := rules.addRenovate(in); err != nil {
+			return rules, fmt.Errorf("%s: %v", name, err)
+		}
+		break
+	}
+
+	for _, name := range dependabotFiles {
+		in, err := os.ReadFile(name)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return rules, err
+		}
+
+		if err := rules.addDependabot(in); err != nil {
+			return rules, fmt.Errorf("%s: %v", name, err)
+		}
+		break
+	}
+
+	return rules, nil
+}
+
+type renovateConfig struct {
+	IgnoreDeps   []string `json:"ignoreDeps"`
+	PackageRules []struct {
+		MatchPackageNames    []string `json:"matchPackageNames"`
+		MatchPackagePatterns []string `json:"matchPackagePatterns"`
+		// the names used before Renovate 25
+		PackageNames    []string `json:"packageNames"`
+		PackagePatterns []string `json:"packagePatterns"`
+
+		MatchUpdateTypes []string `json:"matchUpdateTypes"`
+		Enabled          *bool    `json:"enabled"`
+		AllowedVersions  string   `json:"allowedVersions"`
+	} `json:"packageRules"`
+}
+
+func (r *updateRules) addRenovate(in []byte) error {
+	cfg := renovateConfig{}
+	if err := json.Unmarshal(in, &cfg); err != nil {
+		return err
+	}
+
+	for _, name := range cfg.IgnoreDeps {
+		r.rules = append(r.rules, updateRule{matches: equals(name), apply: func(p *updatePolicy) { p.skip = true }})
+	}
+
+	for _, pr := range cfg.PackageRules {
+		names := append(pr.MatchPackageNames, pr.PackageNames...)
+		patterns := []*regexp.Regexp{}
+		for _, pat := range append(pr.MatchPackagePatterns, pr.PackagePatterns...) {
+			re, err := regexp.Compile(pat)
+			if err != nil {
+				return err
+			}
+			patterns = append(patterns, re)
+		}
+
+		matches := func(name string) bool {
+			if len(names) == 0 && len(patterns) == 0 {
+				return true
+			}
+			for _, n := range names {
+				if n == name {
+					return true
+				}
+			}
+			for _, re := range patterns {
+				if re.MatchString(name) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if pr.Enabled != nil && !*pr.Enabled {
+			types := pr.MatchUpdateTypes
+			r.rules = append(r.rules, updateRule{matches: matches, apply: func(p *updatePolicy) {
+				if len(types) == 0 {
+					p.skip = true
+				}
+				ignoreUpdateTypes(p, types, "")
+			}})
+		}
+
+		if pr.AllowedVersions != "" {
+			apply, err := allowedVersions(pr.AllowedVersions)
+			if err != nil {
+				return err
+			}
+			r.rules = append(r.rules, updateRule{matches: matches, apply: apply})
+		}
+	}
+
+	return nil
+}
+
+// allowedVersions parses a Renovate allowedVersions value: a semver range,
+// or a regex between slashes, optionally negated with "!".
+func allowedVersions(v string) (func(p *updatePolicy), error) {
+	if strings.HasPrefix(v, "/") || strings.HasPrefix(v, "!/") {
+		negate := strings.HasPrefix(v, "!")
+		re, err := regexp.Compile(strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(v, "!"), "/"), "/"))
+		if err != nil {
+			return nil, err
+		}
+		vp := versionPattern{re: re, negate: negate}
+		return func(p *updatePolicy) { p.patterns = append(p.patterns, vp) }, nil
+	}
+
+	c, err := semver.NewConstraint(v)
+	if err != nil {
+		return nil, err
+	}
+	return func(p *updatePolicy) { p.allowed = append(p.allowed, c) }, nil
+}
+
+type dependabotConfig struct {
+	Updates []struct {
+		Ignore []struct {
+			DependencyName string   `yaml:"dependency-name"`
+			Versions       []string `yaml:"versions"`
+			UpdateTypes    []string `yaml:"update-types"`
+		} `yaml:"ignore"`
+	} `yaml:"updates"`
+}
+
+func (r *updateRules) addDependabot(in []byte) error {
+	cfg := dependabotConfig{}
+	if err := yaml.Unmarshal(in, &cfg); err != nil {
+		return err
+	}
+
+	for _, u := range cfg.Updates {
+		for _, ig := range u.Ignore {
+			pattern := ig.DependencyName
+			matches := func(name string) bool {
+				ok, _ := path.Match(pattern, name)
+				return ok
+			}
+
+			ignored := []*semver.Constraints{}
+			for _, v := range ig.Versions {
+				c, err := semver.NewConstraint(v)
+				if err != nil {
+					return err
+				}
+				ignored = append(ignored, c)
+			}
+
+			types := ig.UpdateTypes
+			r.rules = append(r.rules, updateRule{matches: matches, apply: func(p *updatePolicy) {
+				if len(ignored) == 0 && len(types) == 0 {
+					p.skip = true
+				}
+				p.ignored = append(p.ignored, ignored...)
+				ignoreUpdateTypes(p, types, "version-update:semver-")
+			}})
+		}
+	}
+
+	return nil
+}
+
+// ignoreUpdateTypes turns off the update types (major, minor, patch) in
+// types, each spelled with prefix.
+func ignoreUpdateTypes(p *updatePolicy, types []string, prefix string) {
+	for _, t := range types {
+		switch strings.TrimPrefix(t, prefix) {
+		case "major":
+			p.ignoreMajor = true
+		case "minor":
+			p.ignoreMinor = true
+		case "patch":
+			p.ignorePatch = true
+		}
+	}
+}
+
+func equals(want string) func(string) bool {
+	return func(name string) bool { return name == want }
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// keychainService is the service brows's keychain entries are stored
+// under; the account is the GitHub host the token is for, so github.com
+// and an Enterprise Server each keep their own.
+const keychainService = "brows"
+
+var errKeychainUnavailable = errors.New("no supported keychain found (need macOS security or libsecret's secret-tool)")
+
+// keychainGet reads the token stored for host from the OS keychain. A
+// missing entry is reported as an empty token, not an error.
+func keychainGet(host string) (string, error) {
+	var cmd *exec.Cmd
+
+	switch {
+	case runtime.GOOS == "darwin" && hasCommand("security"):
+		cmd = exec.Command("security", "find-generic-password", "-s", keychainService, "-a", host, "-w")
+	case hasCommand("secret-tool"):
+		cmd = exec.Command("secret-tool", "lookup", "service", keychainService, "account", host)
+	default:
+		return "", errKeychainUnavailable
+	}
+
+	out, err := cmd.Output()
+	if err != nil {
+		// both tools exit non-zero when the entry doesn't exist
+		return "", nil
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// keychainSet stores token for host. It's handed to the keychain tool on
+// stdin, never as an argument, where other users' ps could see it.
+func keychainSet(host, token string) error {
+	var cmd *exec.Cmd
+
+	switch {
+	case runtime.GOOS == "darwin" && hasCommand("security"):
+		// security prompts for -w without a value on the terminal rather
+		// than stdin, so the command itself is read from stdin instead
+		cmd = exec.Command("security", "-i")
+		cmd.Stdin = strings.NewReader(fmt.Sprintf("add-generic-password -U -s %s -a %s -w %s\n", keychainService, strconv.Quote(host), strconv.Quote(token)))
+	case hasCommand("secret-tool"):
+		cmd = exec.Command("secret-tool", "store", "--label=brows token for "+host, "service", keychainService, "account", host)
+		cmd.Stdin = strings.NewReader(token)
+	default:
+		return errKeychainUnavailable
+	}
+
+	if err := runQuiet(cmd); err != nil {
+		return err
+	}
+
+	// security -i carries on past a command that fails
+	if stored, err := keychainGet(host); err != nil || stored != token {
+		return fmt.Errorf("%s didn't store the token", cmd.Args[0])
+	}
+	return nil
+}
+
+// keychainDelete removes the token stored for host. Deleting an entry
+// that doesn't exist is not an error.
+func keychainDelete(host string) error {
+	existing, err := keychainGet(host)
+	if err != nil || existing == "" {
+		return err
+	}
+
+	var cmd *exec.Cmd
+
+	switch {
+	case runtime.GOOS == "darwin" && hasCommand("security"):
+		cmd = exec.Command("security", "delete-generic-password", "-s", keychainService, "-a", host)
+	default:
+		cmd = exec.Command("secret-tool", "clear", "service", keychainService, "account", host)
+	}
+
+	return runQuiet(cmd)
+}
+
+func hasCommand(name string) bool {
+	_, err := exec.LookPath(name)
+	return err == nil
+}
+
+func runQuiet(cmd *exec.Cmd) error {
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s: %v: %s", cmd.Args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
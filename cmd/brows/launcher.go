@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/rubysolo/brows/pkg/releases"
+	"github.com/rubysolo/brows/pkg/ui"
+)
+
+// runLauncher offers the recently browsed repositories, and any other one
+// typed in, when brows is run without arguments.
+func runLauncher() int {
+	known := ui.Options{Store: AppStore, Pins: AppConfig.Pins, Aliases: AppConfig.Aliases}
+	return runTUI(ui.NewLauncher(known, openRepo))
+}
+
+// runStarred lists the user's starred repositories with their latest
+// releases, to browse any of them.
+func runStarred() int {
+	provider, err := newProvider()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	lister, ok := provider.(releases.StarLister)
+	if !ok {
+		fmt.Fprintln(os.Stderr, "--starred needs GitHub")
+		return 1
+	}
+
+	fmt.Fprintln(os.Stderr, "listing starred repositories...")
+	starred, err := lister.ListStarred(context.Background())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	return runTUI(ui.NewStarredLauncher(ui.Options{Store: AppStore}, starred, openRepo))
+}
+
+// openRepo builds the release viewer for a repository picked or typed in
+// a launcher.
+func openRepo(arg, version string) (ui.Model, error) {
+	// a typed URL picks its provider, so that's built only now
+	name, err := projectURL(arg)
+	if err != nil {
+		return ui.Model{}, err
+	}
+	owner, repo, err := splitRepo(name)
+	if err != nil {
+		return ui.Model{}, err
+	}
+
+	provider, err := providerFor(owner, repo)
+	if err != nil {
+		return ui.Model{}, err
+	}
+
+	opts, err := uiOptions(provider)
+	if err != nil {
+		return ui.Model{}, err
+	}
+	opts.StableOnly = opts.StableOnly || repoConfig(owner, repo).StableOnly
+
+	return ui.New(provider, owner, repo, version, opts)
+}
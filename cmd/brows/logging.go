@@ -0,0 +1,38 @@
+package main
+
+import (
+	"io"
+	"log"
+	"log/slog"
+	"os"
+	"path/filepath"
+)
+
+const logPath = "$HOME/.local/state/brows/brows.log"
+
+// setupLogging routes slog output to a file when --verbose is set, so log
+// lines never land on the terminal the TUI is drawing to. Without
+// --verbose, logging is discarded. The standard log package, which
+// slog.SetDefault redirects too, keeps writing to stderr either way.
+func setupLogging(verbose bool) (io.Closer, error) {
+	if !verbose {
+		slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+		log.SetOutput(os.Stderr)
+		return io.NopCloser(nil), nil
+	}
+
+	path := os.ExpandEnv(logPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	handler := slog.NewTextHandler(f, &slog.HandlerOptions{Level: slog.LevelDebug})
+	slog.SetDefault(slog.New(handler))
+
+	return f, nil
+}
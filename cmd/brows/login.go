@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// oauthClientID is the client ID of the OAuth app brows logs in as, set at
+// build time with -ldflags "-X main.oauthClientID=...", as releases are
+// from BROWS_OAUTH_CLIENT_ID. The oauth_client_id config key overrides it,
+// for Enterprise Servers, which need an app of their own.
+var oauthClientID = ""
+
+// loginScopes are the scopes asked for: repo, so private repositories can
+// be browsed too.
+const loginScopes = "repo"
+
+// deviceCode is GitHub's answer to the start of a device flow login.
+type deviceCode struct {
+	DeviceCode      string `json:"device_code"`
+	UserCode        string `json:"user_code"`
+	VerificationURI string `json:"verification_uri"`
+	ExpiresIn       int    `json:"expires_in"`
+	Interval        int    `json:"interval"`
+}
+
+// runLogin logs in with GitHub's OAuth device flow: the user enters a code
+// on GitHub in their browser, and the token brows is granted goes to the
+// OS keychain.
+func runLogin() int {
+	clientID := AppConfig.OAuthClientID
+	if clientID == "" {
+		clientID = oauthClientID
+	}
+	if clientID == "" {
+		fmt.Fprintln(os.Stderr, "This build of brows has no OAuth app to log in with. Register one with device flow")
+		fmt.Fprintln(os.Stderr, "enabled and set oauth_client_id in the config, or use a token (see brows auth status).")
+		return 1
+	}
+
+	httpClient, err := newHTTPClient(AppConfig)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	// the device flow runs on the server the token is for: --base-url, a
+	// project URL's host or github_base_url, like every API call
+	web := "https://github.com/"
+	if base := githubBaseURL(AppConfig); base != "" {
+		web = strings.TrimSuffix(base, "/") + "/"
+	}
+	ctx := context.Background()
+
+	var code deviceCode
+	if err := oauthPost(ctx, httpClient, web+"login/device/code", url.Values{
+		"client_id": {clientID},
+		"scope":     {loginScopes},
+	}, &code); err != nil {
+		fmt.Fprintln(os.Stderr, "Could not start the login:", err)
+		return 1
+	}
+
+	fmt.Printf("Open %s and enter the code %s\n", code.VerificationURI, code.UserCode)
+	fmt.Println("Waiting for GitHub...")
+
+	token, err := pollForToken(ctx, httpClient, web, clientID, code)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Login failed:", err)
+		return 1
+	}
+
+	if err := keychainSet(githubHost(AppConfig), token); err != nil {
+		fmt.Fprintln(os.Stderr, "Could not store the token in the keychain:", err)
+		return 1
+	}
+
+	client, err := newGitHubClient(AppConfig, token)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	user, _, err := client.Users.Get(ctx, "")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Stored the token, but checking it failed:", err)
+		return 1
+	}
+
+	fmt.Printf("Logged in as %s; the token is in the keychain.\n", user.GetLogin())
+	writeIdentity(token, identity{Source: "keychain", Login: user.GetLogin()})
+
+	for _, source := range tokenSources(AppConfig) {
+		if source == "keychain" {
+			break
+		}
+		if token, _ := lookupToken(source, AppConfig); strings.TrimSpace(token) != "" {
+			fmt.Printf("Note: %s is checked first and still has a token, so it's the one used.\n", source)
+			break
+		}
+	}
+	return 0
+}
+
+// pollForToken waits for the user to enter the code, asking GitHub as
+// often as it allows.
+func pollForToken(ctx context.Context, client *http.Client, web, clientID string, code deviceCode) (string, error) {
+	interval := time.Duration(max(code.Interval, 5)) * time.Second
+	deadline := time.Now().Add(time.Duration(code.ExpiresIn) * time.Second)
+
+	for time.Now().Before(deadline) {
+		time.Sleep(interval)
+
+		var answer struct {
+			AccessToken string `json:"access_token"`
+			Error       string `json:"error"`
+			Description string `json:"error_description"`
+		}
+		if err := oauthPost(ctx, client, web+"login/oauth/access_token", url.Values{
+			"client_id":   {clientID},
+			"device_code": {code.DeviceCode},
+			"grant_type":  {"urn:ietf:params:oauth:grant-type:device_code"},
+		}, &answer); err != nil {
+			return "", err
+		}
+
+		switch answer.Error {
+		case "":
+			return answer.AccessToken, nil
+		case "authorization_pending":
+		case "slow_down":
+			interval += 5 * time.Second
+		default:
+			return "", errors.New(answer.Description)
+		}
+	}
+
+	return "", errors.New("the code expired; run brows auth login again")
+}
+
+// oauthPost posts form to one of GitHub's OAuth endpoints, which answer in
+// JSON when asked to.
+func oauthPost(ctx context.Context, client *http.Client, endpoint string, form url.Values, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", userAgent(AppConfig))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: %s", endpoint, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
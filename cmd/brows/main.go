@@ -0,0 +1,626 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/masterminds/semver"
+	"github.com/rubysolo/brows/pkg/releases"
+	"github.com/rubysolo/brows/pkg/store"
+	"github.com/rubysolo/brows/pkg/ui"
+)
+
+// version is set at build time by goreleaser.
+var version = "dev"
+
+// AppStore holds cached data and local state, shared by every subcommand.
+var AppStore *store.Store
+
+func main() {
+	args := os.Args[1:]
+	// the completion scripts pass along half-typed flags, which mustn't be
+	// parsed
+	if len(args) == 0 || args[0] != "__complete" {
+		args = parseArgs(args)
+	}
+
+	logFile, err := setupLogging(*verbose)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "fatal:", err)
+		os.Exit(1)
+	}
+	defer logFile.Close()
+
+	ReadConfig()
+	if err := useProfile(); err != nil {
+		fmt.Fprintln(os.Stderr, "fatal:", err)
+		os.Exit(1)
+	}
+
+	AppStore, err = store.Open(store.DefaultPath())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "fatal: reading state:", err)
+		os.Exit(1)
+	}
+
+	if len(args) > 0 {
+		switch args[0] {
+		case "logout":
+			os.Exit(runLogout())
+		case "auth":
+			os.Exit(runAuth(args[1:]))
+		case "state":
+			os.Exit(runState(args[1:]))
+		case "status":
+			os.Exit(runStatus())
+		case "outdated":
+			os.Exit(runOutdated())
+		case "get":
+			os.Exit(runGet(args[1:]))
+		case "export":
+			os.Exit(runExport(args[1:]))
+		case "config":
+			os.Exit(runConfig(args[1:]))
+		case "watch":
+			os.Exit(runWatch(args[1:]))
+		case "completion":
+			os.Exit(runCompletion(args[1:]))
+		case "__complete":
+			os.Exit(runComplete(args[1:]))
+		}
+	}
+
+	if *fromGomod || *fromGemfile || *fromNpm != "" || *fromCargo != "" || *fromPypi != "" {
+		dep, err := fromManifest(args)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		args = []string{dep.Repo, dep.Version}
+	}
+
+	if *all {
+		os.Exit(runDashboard())
+	}
+
+	if *starred {
+		os.Exit(runStarred())
+	}
+
+	if len(args) < 1 {
+		if repo, ok := originRepo(); ok {
+			args = []string{repo}
+		}
+	}
+
+	if len(args) < 1 {
+		if !*check && !*jsonOutput && !*plain && !*raw && isTerminal(os.Stdout) {
+			os.Exit(runLauncher())
+		}
+		usage()
+		os.Exit(1)
+	}
+
+	if *check {
+		os.Exit(runCheck(args))
+	}
+
+	browse(args)
+}
+
+// fromManifest finds the dependency --from-gomod, --from-gemfile,
+// --from-npm, --from-cargo or --from-pypi asks for, or the one picked
+// from the manifest when args doesn't name one.
+func fromManifest(args []string) (dependency, error) {
+	if *fromNpm != "" {
+		return npmDependency(*fromNpm)
+	}
+	if *fromCargo != "" {
+		return cargoDependency(*fromCargo)
+	}
+	if *fromPypi != "" {
+		return pypiDependency(*fromPypi)
+	}
+
+	name := ""
+	if len(args) > 0 {
+		name = args[0]
+	}
+
+	if *fromGomod {
+		deps, err := readGoMod("go.mod")
+		if err != nil {
+			return dependency{}, err
+		}
+		return chooseDependency(deps, name, "go.mod")
+	}
+
+	deps, err := readGemfileLock("Gemfile.lock")
+	if err != nil {
+		return dependency{}, err
+	}
+	dep, err := chooseDependency(deps, name, "Gemfile.lock")
+	if err != nil || dep.Repo != "" {
+		return dep, err
+	}
+
+	client, err := newHTTPClient(AppConfig)
+	if err != nil {
+		return dependency{}, err
+	}
+	dep.Repo, err = gemRepository(client, dep.Name)
+	return dep, err
+}
+
+// splitRepo turns "owner/repo", an alias or a bare "repo" (in the default
+// org) into its parts.
+func splitRepo(arg string) (string, string, error) {
+	if target, ok := AppConfig.Aliases[arg]; ok {
+		arg = target
+	}
+
+	parts := strings.Split(arg, "/")
+	if len(parts) == 1 {
+		if AppConfig.DefaultOrg == "" {
+			return "", "", fmt.Errorf("No organization specified, and no default organization configured.")
+		}
+		return AppConfig.DefaultOrg, arg, nil
+	}
+
+	// GitLab projects can sit in nested groups
+	return strings.Join(parts[:len(parts)-1], "/"), parts[len(parts)-1], nil
+}
+
+// projectURL turns a project's web URL into owner/repo, choosing the
+// provider it belongs to: gitlab.com, or any host with "gitlab" in its
+// name, is GitLab; codeberg.org, the configured gitea_base_url, or any
+// host with "gitea" or "forgejo" in its name, is Gitea; bitbucket.org is
+// Bitbucket; github.com is GitHub. Another host is only taken to be a
+// GitHub Enterprise Server when it's the one --base-url or github_base_url
+// names, or --provider github is given: a GitHub token must never be sent
+// to a host nobody said was GitHub. Any other host is an error.
+// Git remotes, like git@github.com:owner/repo.git, are taken to be on the
+// host's web interface. Anything that isn't a URL is returned as it is.
+func projectURL(arg string) (string, error) {
+	u, err := url.Parse(sshRemote(arg))
+	if err != nil || u.Host == "" {
+		return arg, nil
+	}
+	switch u.Scheme {
+	case "https", "http":
+	case "ssh", "git+ssh", "git":
+		// the web interface doesn't share the ssh user or port
+		u = &url.URL{Scheme: "https", Host: u.Hostname(), Path: u.Path}
+	default:
+		return arg, nil
+	}
+
+	path := strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git")
+	// drop GitLab's "/-/releases" and GitHub's "/releases/tag/x" and the like
+	if i := strings.Index(path, "/-/"); i >= 0 {
+		path = path[:i]
+	}
+	root := u.Scheme + "://" + u.Host + "/"
+
+	switch {
+	case u.Host == "github.com":
+		*providerName = "github"
+	case strings.Contains(u.Host, "gitlab") || *providerName == "gitlab":
+		*providerName = "gitlab"
+		AppConfig.GitLabBaseURL = root
+		return path, nil
+	case u.Host == "bitbucket.org" || *providerName == "bitbucket":
+		*providerName = "bitbucket"
+	case giteaHost(u.Hostname()) || *providerName == "gitea" || *providerName == "forgejo":
+		*providerName = "gitea"
+		AppConfig.GiteaBaseURL = root
+	case githubEnterpriseHost(u.Hostname()) || flagSet("provider") && *providerName == "github":
+		*providerName = "github"
+		*baseURL = root
+	default:
+		return "", fmt.Errorf("unknown host %s: pass --provider, or --base-url for a GitHub Enterprise Server", u.Host)
+	}
+
+	parts := strings.Split(path, "/")
+	if len(parts) > 2 {
+		parts = parts[:2]
+	}
+	return strings.Join(parts, "/"), nil
+}
+
+// githubEnterpriseHost reports whether host is the GitHub Enterprise
+// Server that --base-url or github_base_url names.
+func githubEnterpriseHost(host string) bool {
+	b, err := url.Parse(githubBaseURL(AppConfig))
+	return err == nil && b.Host != "" && b.Hostname() == host
+}
+
+// giteaHost reports whether host looks like a Gitea or Forgejo instance:
+// Codeberg, the configured one, or one named for either.
+func giteaHost(host string) bool {
+	if host == "codeberg.org" || strings.Contains(host, "gitea") || strings.Contains(host, "forgejo") {
+		return true
+	}
+	b, err := url.Parse(AppConfig.GiteaBaseURL)
+	return err == nil && b.Hostname() == host
+}
+
+// webProvider is a releases.Provider that knows where its forge's web
+// interface is.
+type webProvider interface {
+	releases.Provider
+	WebURL() string
+}
+
+// newProvider builds the provider chosen with --provider or the provider
+// config key, GitHub by default, from the configured credentials.
+func newProvider() (webProvider, error) {
+	name := *providerName
+	if name == "" {
+		name = AppConfig.Provider
+	}
+
+	switch name {
+	case "", "github":
+		return newGitHubProvider()
+	case "gitlab":
+		return newGitLabProvider()
+	case "gitea", "forgejo":
+		return newGiteaProvider()
+	case "bitbucket":
+		return newBitbucketProvider()
+	default:
+		return nil, fmt.Errorf("unknown provider %q (expected github, gitlab, gitea, forgejo or bitbucket)", name)
+	}
+}
+
+// providerFor builds the provider for owner/repo, on the forge its repos
+// entry in the config names, if it has one.
+func providerFor(owner, repo string) (webProvider, error) {
+	saved := AppConfig
+	AppConfig = forRepo(owner, repo)
+	defer func() { AppConfig = saved }()
+
+	return newProvider()
+}
+
+func newGitLabProvider() (*releases.GitLabProvider, error) {
+	client, err := newHTTPClient(AppConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	token := os.Getenv("GITLAB_TOKEN")
+	if token == "" {
+		token = AppConfig.GitLabToken
+	}
+
+	slog.Debug("provider selected", "provider", "gitlab", "base_url", AppConfig.GitLabBaseURL, "token", token != "")
+
+	provider := releases.NewGitLabProvider(client, AppConfig.GitLabBaseURL, token)
+	for key, rc := range AppConfig.Repos {
+		if owner, repo, err := splitRepo(key); err == nil {
+			provider.WithTagPrefix(owner, repo, rc.TagPrefix)
+		}
+	}
+	return provider, nil
+}
+
+func newGiteaProvider() (*releases.GiteaProvider, error) {
+	client, err := newHTTPClient(AppConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	token := os.Getenv("GITEA_TOKEN")
+	if token == "" {
+		token = AppConfig.GiteaToken
+	}
+
+	slog.Debug("provider selected", "provider", "gitea", "base_url", AppConfig.GiteaBaseURL, "token", token != "")
+
+	provider := releases.NewGiteaProvider(client, AppConfig.GiteaBaseURL, token)
+	for key, rc := range AppConfig.Repos {
+		if owner, repo, err := splitRepo(key); err == nil {
+			provider.WithTagPrefix(owner, repo, rc.TagPrefix)
+		}
+	}
+	return provider, nil
+}
+
+func newBitbucketProvider() (*releases.BitbucketProvider, error) {
+	client, err := newHTTPClient(AppConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	username, token := os.Getenv("BITBUCKET_USERNAME"), os.Getenv("BITBUCKET_TOKEN")
+	if token == "" {
+		username, token = AppConfig.BitbucketUsername, AppConfig.BitbucketToken
+	}
+
+	slog.Debug("provider selected", "provider", "bitbucket", "token", token != "")
+
+	provider := releases.NewBitbucketProvider(client, username, token)
+	for key, rc := range AppConfig.Repos {
+		if owner, repo, err := splitRepo(key); err == nil {
+			provider.WithTagPrefix(owner, repo, rc.TagPrefix)
+		}
+	}
+	return provider, nil
+}
+
+// githubAnonymous is set when no GitHub token was found, and public
+// repositories are browsed without one.
+var githubAnonymous bool
+
+// newGitHubProvider builds the GitHub provider from the configured
+// credentials. Without any, it browses anonymously, at GitHub's lower rate
+// limit, unless --token-source asked for a particular one. A source that
+// fails is reported rather than browsed past.
+func newGitHubProvider() (*releases.GitHubProvider, error) {
+	token, source, err := findToken(tokenSources(AppConfig), AppConfig)
+	switch {
+	case errors.Is(err, errNoToken) && *tokenSource == "":
+		slog.Debug("no token found, browsing anonymously", "err", err)
+		githubAnonymous = true
+	case err != nil:
+		return nil, err
+	default:
+		slog.Debug("token source selected", "source", source)
+	}
+
+	client, err := newGitHubClient(AppConfig, token)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("provider selected", "provider", "github")
+
+	// GraphQL needs a token, and its POSTs can't be answered from the cache
+	graphql := token != "" && !*offline && !AppConfig.NoGraphQL
+
+	provider := releases.NewGitHubProvider(client).
+		WithBudget(releases.NewBudget(AppConfig.RateReserve)).
+		WithGraphQL(graphql)
+	for key, rc := range AppConfig.Repos {
+		if owner, repo, err := splitRepo(key); err == nil {
+			provider.WithTagPrefix(owner, repo, rc.TagPrefix)
+		}
+	}
+	return provider, nil
+}
+
+// newDownloader verifies assets from the forge at web the way the config
+// asks. It downloads through a copy of client without its timeout, which
+// big assets on slow links would run into; they're bounded by their
+// context instead.
+func newDownloader(client *http.Client, web string) releases.Downloader {
+	unbounded := *client
+	unbounded.Timeout = 0
+
+	return releases.Downloader{
+		Client:          &unbounded,
+		AllowUnverified: *unverified,
+		Overwrite:       *force,
+		CosignKey:       os.ExpandEnv(AppConfig.CosignKey),
+		CertIdentity:    AppConfig.CosignIdentity,
+		CertIssuer:      AppConfig.CosignIssuer,
+		WebURL:          web,
+	}
+}
+
+// target is a repository to browse and the versions to browse it from.
+type target struct {
+	owner, repo    string
+	version, until string
+}
+
+// browseTargets reads the repositories to browse from args: either
+// "owner/repo [version | from..to]", or any number of "owner/repo[@version]",
+// each opened in its own tab.
+func browseTargets(args []string) ([]target, error) {
+	specs := [][2]string{}
+
+	if len(args) == 2 && isVersionArg(args[1]) {
+		specs = append(specs, [2]string{args[0], args[1]})
+	} else {
+		for _, arg := range args {
+			// a remote's "git@" isn't a version
+			name, err := projectURL(arg)
+			if err != nil {
+				return nil, err
+			}
+			repo, version, _ := strings.Cut(name, "@")
+			specs = append(specs, [2]string{repo, version})
+		}
+	}
+
+	targets := []target{}
+	for _, spec := range specs {
+		// "1.2.0..2.0.0" limits browsing to the releases in between
+		version, until, _ := strings.Cut(spec[1], "..")
+
+		name, err := projectURL(spec[0])
+		if err != nil {
+			return nil, err
+		}
+		owner, repo, err := splitRepo(name)
+		if err != nil {
+			return nil, err
+		}
+
+		// in a checkout of the repository, start from the tag it's at
+		if spec[1] == "" && (*detect || isOrigin(owner, repo)) {
+			if tag, ok := describeVersion(); ok {
+				version = tag
+			}
+		}
+		if version == "" {
+			version = "0.0.0"
+		}
+		targets = append(targets, target{owner, repo, version, until})
+	}
+
+	return targets, nil
+}
+
+// isVersionArg tells a version, range or constraint apart from a
+// repository name.
+func isVersionArg(arg string) bool {
+	if _, ok := releases.ParseConstraint(arg); ok && !strings.Contains(arg, "/") {
+		return true
+	}
+	from, to, isRange := strings.Cut(arg, "..")
+	if from == "" {
+		from = "0.0.0"
+	}
+	if _, err := semver.NewVersion(from); err != nil {
+		return false
+	}
+	if isRange {
+		_, err := semver.NewVersion(to)
+		return err == nil
+	}
+	return true
+}
+
+func browse(args []string) {
+	targets, err := browseTargets(args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if *jsonOutput || *plain || *raw || !isTerminal(os.Stdout) {
+		if len(targets) > 1 {
+			fmt.Fprintln(os.Stderr, "--plain and --json take one repository at a time")
+			os.Exit(1)
+		}
+
+		t := targets[0]
+		provider, err := providerFor(t.owner, t.repo)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		if repoConfig(t.owner, t.repo).StableOnly {
+			*stableOnly = true
+		}
+
+		if *jsonOutput {
+			os.Exit(runJSON(provider, t.owner, t.repo, t.version, t.until))
+		}
+		os.Exit(runPlain(provider, t.owner, t.repo, t.version, t.until))
+	}
+
+	models := make([]ui.Model, len(targets))
+	for i, t := range targets {
+		provider, err := providerFor(t.owner, t.repo)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		opts, err := uiOptions(provider)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		opts.Until = t.until
+		opts.StableOnly = opts.StableOnly || repoConfig(t.owner, t.repo).StableOnly
+
+		if models[i], err = ui.New(provider, t.owner, t.repo, t.version, opts); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	os.Exit(runTUI(ui.NewTabs(models...)))
+}
+
+// uiOptions configures the release viewer from the flags and config.
+func uiOptions(provider webProvider) (ui.Options, error) {
+	httpClient, err := newHTTPClient(AppConfig)
+	if err != nil {
+		return ui.Options{}, err
+	}
+
+	style, err := glamourStyle()
+	if err != nil {
+		return ui.Options{}, err
+	}
+
+	_, isGitHub := provider.(*releases.GitHubProvider)
+
+	return ui.Options{
+		Store:           AppStore,
+		NoHyperlinks:    AppConfig.NoHyperlinks,
+		ScrollStep:      AppConfig.ScrollStep,
+		ScrollPastEnd:   AppConfig.ScrollPastEnd,
+		MouseWheelLines: AppConfig.MouseWheelLines,
+		JumpToBreaking:  AppConfig.JumpToBreaking,
+		ListLayout:      AppConfig.ListLayout,
+		Style:           style,
+		Aggregate:       *aggregate,
+		StableOnly:      *stableOnly,
+		StaleMajors:     AppConfig.StaleMajors,
+		StaleMonths:     AppConfig.StaleMonths,
+		WebURL:          provider.WebURL(),
+		Downloader:      newDownloader(httpClient, provider.WebURL()),
+		DownloadDir:     os.ExpandEnv(AppConfig.DownloadDir),
+		ImageProtocol:   AppConfig.ImageProtocol,
+		Pins:            AppConfig.Pins,
+		Aliases:         AppConfig.Aliases,
+		Offline:         servedOffline.Load,
+		RateLimit:       quota.get,
+		Anonymous:       isGitHub && githubAnonymous,
+	}, nil
+}
+
+// runTUI runs model full screen, and returns the exit status: 2 if it
+// crashed, 1 if it stopped on an error.
+func runTUI(model tea.Model) int {
+	if len(os.Getenv("DEBUG")) > 0 {
+		f, err := tea.LogToFile("debug.log", "debug")
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "fatal:", err)
+			return 1
+		}
+		defer f.Close()
+	}
+
+	guard := newCrashGuard(model)
+	programOpts := []tea.ProgramOption{tea.WithAltScreen()}
+	if !*noMouse && !AppConfig.NoMouse {
+		programOpts = append(programOpts, tea.WithMouseCellMotion())
+	}
+
+	p := tea.NewProgram(guard, programOpts...)
+	guard.program = p
+
+	_, err := p.Run()
+
+	if report := guard.Report(); report != "" {
+		fmt.Fprintln(os.Stderr, report)
+		return 2
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Alas, there's been an error: %v", err)
+		return 1
+	}
+
+	if final, ok := guard.model.(interface{ Err() error }); ok && final.Err() != nil {
+		fmt.Fprintln(os.Stderr, final.Err())
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"flag"
+	"testing"
+)
+
+func TestProjectURL(t *testing.T) {
+	tests := []struct {
+		name         string
+		arg          string
+		githubBase   string
+		want         string
+		wantProvider string
+		wantBaseURL  string
+		wantErr      bool
+	}{
+		{name: "owner/repo", arg: "acme/tool", want: "acme/tool"},
+		{name: "GitHub", arg: "https://github.com/acme/tool", want: "acme/tool", wantProvider: "github"},
+		{name: "GitHub release page", arg: "https://github.com/acme/tool/releases/tag/v1.0.0", want: "acme/tool", wantProvider: "github"},
+		{name: "clone URL", arg: "https://github.com/acme/tool.git", want: "acme/tool", wantProvider: "github"},
+		{name: "scp-style remote", arg: "git@github.com:acme/tool.git", want: "acme/tool", wantProvider: "github"},
+		{name: "GitLab subgroup", arg: "https://gitlab.com/group/sub/tool/-/releases", want: "group/sub/tool", wantProvider: "gitlab"},
+		{name: "Bitbucket", arg: "https://bitbucket.org/acme/tool", want: "acme/tool", wantProvider: "bitbucket"},
+		{name: "Codeberg", arg: "https://codeberg.org/acme/tool", want: "acme/tool", wantProvider: "gitea"},
+		{
+			name:         "configured GitHub Enterprise Server",
+			arg:          "https://github.example.com/acme/tool",
+			githubBase:   "https://github.example.com/api/v3/",
+			want:         "acme/tool",
+			wantProvider: "github",
+			wantBaseURL:  "https://github.example.com/",
+		},
+		{name: "unknown host", arg: "https://git.example.com/acme/tool", wantErr: true},
+		{
+			name:       "host other than the configured server",
+			arg:        "https://evil.example.com/acme/tool",
+			githubBase: "https://github.example.com/api/v3/",
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			AppConfig = &Config{GitHubBaseURL: tt.githubBase}
+			*providerName, *baseURL = "", ""
+
+			got, err := projectURL(tt.arg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("projectURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got != tt.want {
+				t.Errorf("projectURL() = %q, want %q", got, tt.want)
+			}
+			if *providerName != tt.wantProvider {
+				t.Errorf("provider = %q, want %q", *providerName, tt.wantProvider)
+			}
+			if *baseURL != tt.wantBaseURL {
+				t.Errorf("base URL = %q, want %q", *baseURL, tt.wantBaseURL)
+			}
+		})
+	}
+}
+
+// TestProjectURLExplicitProvider runs last: once --provider is set, it
+// stays set for the rest of the test binary.
+func TestProjectURLExplicitProvider(t *testing.T) {
+	AppConfig = &Config{}
+	*baseURL = ""
+	if err := flag.Set("provider", "github"); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := projectURL("https://git.example.com/acme/tool")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "acme/tool" || *baseURL != "https://git.example.com/" {
+		t.Errorf("projectURL() = %q with base URL %q", got, *baseURL)
+	}
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// dependency is a repository a project depends on, at the version it's
+// currently on.
+type dependency struct {
+	Name    string // the package name the manifest uses
+	Repo    string // owner/repo
+	Version string
+	Source  string // the manifest it was read from
+}
+
+// arg formats d the way --check takes it.
+func (d dependency) arg() string {
+	return d.Repo + "@" + d.Version
+}
+
+// manifests are the files brows knows how to read dependencies from, in
+// the order they're looked for. Registries are asked where packages
+// from npm, rubygems.org, crates.io and PyPI are developed.
+var manifests = []struct {
+	name string
+	read func(path string) ([]dependency, error)
+	// registry is set when reading it asks a registry about each package.
+	registry bool
+}{
+	{"deps.yml", readWorkspace, false},
+	{"go.mod", readGoMod, false},
+	{"package.json", readPackageJSON, true},
+	{"Gemfile.lock", readGemfile, true},
+	{"Cargo.toml", readCargoToml, true},
+	{"requirements.txt", readRequirements, true},
+}
+
+// loadDependencies reads the --workspace file if one was given, and
+// otherwise every known manifest found in the current directory. Without
+// registries, the manifests that need a registry lookup per package are
+// left out.
+func loadDependencies(registries bool) ([]dependency, error) {
+	if *workspaceFile != "" {
+		return readWorkspace(*workspaceFile)
+	}
+
+	deps := []dependency{}
+	found := false
+	skipped := []string{}
+	for _, m := range manifests {
+		if _, err := os.Stat(m.name); err != nil {
+			continue
+		}
+		if m.registry && !registries {
+			skipped = append(skipped, m.name)
+			continue
+		}
+		found = true
+
+		read, err := m.read(m.name)
+		if err != nil {
+			return nil, err
+		}
+		deps = append(deps, read...)
+	}
+
+	if !found && len(skipped) > 0 {
+		return nil, fmt.Errorf("%s need a registry lookup per package, which --short skips; pass --workspace", strings.Join(skipped, " and "))
+	}
+	if !found {
+		names := make([]string, len(manifests))
+		for i, m := range manifests {
+			names[i] = m.name
+		}
+		return nil, fmt.Errorf("no %s in the current directory; pass --workspace", strings.Join(names, " or "))
+	}
+
+	return deps, nil
+}
+
+// workspace lists the dependencies of a project, each as
+// owner/repo@version, like the arguments to --check:
+//
+//	deps:
+//	  - charmbracelet/bubbletea@0.22.0
+//	  - charmbracelet/glamour@0.5.0
+type workspace struct {
+	Deps []string `yaml:"deps"`
+}
+
+func readWorkspace(path string) ([]dependency, error) {
+	in, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	ws := workspace{}
+	if err := yaml.Unmarshal(in, &ws); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+
+	deps := make([]dependency, len(ws.Deps))
+	for i, d := range ws.Deps {
+		repo, version, _ := strings.Cut(d, "@")
+		deps[i] = dependency{Name: repo, Repo: repo, Version: version, Source: filepath.Base(path)}
+	}
+
+	return deps, nil
+}
+
+// readGoMod lists the direct requirements of a go.mod that are hosted on
+// GitHub. Modules below a repository's root, and major version suffixes,
+// map to the repository itself; only the first module of each repository
+// is kept.
+func readGoMod(path string) ([]dependency, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	deps := []dependency{}
+	seen := map[string]bool{}
+	inRequire := false
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case line == "require (":
+			inRequire = true
+			continue
+		case inRequire && line == ")":
+			inRequire = false
+			continue
+		case strings.HasPrefix(line, "require "):
+			line = strings.TrimPrefix(line, "require ")
+		case !inRequire:
+			continue
+		}
+
+		if strings.HasSuffix(line, "// indirect") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		repo, ok := githubRepo(fields[0])
+		if !ok || seen[repo] {
+			continue
+		}
+		seen[repo] = true
+
+		deps = append(deps, dependency{Name: fields[0], Repo: repo, Version: fields[1], Source: filepath.Base(path)})
+	}
+
+	return deps, scanner.Err()
+}
+
+// githubRepo maps a github.com module path to owner/repo.
+func githubRepo(module string) (string, bool) {
+	parts := strings.Split(module, "/")
+	if len(parts) < 3 || parts[0] != "github.com" {
+		return "", false
+	}
+	return parts[1] + "/" + parts[2], true
+}
+
+// githubRepoURL maps the ways package registries name a GitHub repository
+// ("https://github.com/owner/repo", "git+ssh://git@github.com/owner/repo.git",
+// "github:owner/repo" or a bare "owner/repo") to owner/repo.
+func githubRepoURL(s string) (string, bool) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimSuffix(s, "/")
+	s = strings.TrimSuffix(s, ".git")
+
+	if rest, ok := strings.CutPrefix(s, "github:"); ok {
+		s = rest
+	} else if i := strings.Index(s, "github.com"); i >= 0 {
+		s = strings.TrimLeft(s[i+len("github.com"):], ":/")
+	} else if strings.Contains(s, ":") {
+		return "", false
+	}
+
+	parts := strings.Split(s, "/")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", false
+	}
+	// drop /tree/main/packages/x and the like
+	return parts[0] + "/" + strings.SplitN(parts[1], "#", 2)[0], true
+}
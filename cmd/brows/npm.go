@@ -0,0 +1,207 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const defaultNpmRegistry = "https://registry.npmjs.org/"
+
+// npmDependency finds the installed version of the npm package name and
+// the GitHub repository its registry metadata points at.
+func npmDependency(name string) (dependency, error) {
+	lock, err := readPackageLock()
+	if err != nil {
+		return dependency{}, err
+	}
+
+	version, source, err := npmInstalledVersion(lock, name)
+	if err != nil {
+		return dependency{}, err
+	}
+
+	client, err := newHTTPClient(AppConfig)
+	if err != nil {
+		return dependency{}, err
+	}
+
+	repo, err := npmRepository(client, name, version)
+	if err != nil {
+		return dependency{}, err
+	}
+
+	return dependency{Name: name, Repo: repo, Version: version, Source: source}, nil
+}
+
+// readPackageJSON lists the dependencies and devDependencies of a
+// package.json at their installed versions, skipping any that aren't
+// installed or whose repository isn't on GitHub.
+func readPackageJSON(path string) ([]dependency, error) {
+	in, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg := struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}{}
+	if err := json.Unmarshal(in, &pkg); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+
+	lock, err := readPackageLock()
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for name := range pkg.Dependencies {
+		names = append(names, name)
+	}
+	for name := range pkg.DevDependencies {
+		if _, ok := pkg.Dependencies[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	deps := []dependency{}
+	for _, name := range names {
+		version, _, err := npmInstalledVersion(lock, name)
+		if err != nil {
+			slog.Debug("skipping npm package", "package", name, "err", err)
+			continue
+		}
+		deps = append(deps, dependency{Name: name, Version: version, Source: filepath.Base(path)})
+	}
+
+	client, err := newHTTPClient(AppConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return resolveRepos(deps, func(d dependency) (string, error) {
+		return npmRepository(client, d.Name, d.Version)
+	}), nil
+}
+
+// packageLock covers both lockfile layouts: "packages" keyed by install
+// path (lockfileVersion 2 and 3) and "dependencies" keyed by name (1).
+type packageLock struct {
+	Packages map[string]struct {
+		Version string `json:"version"`
+	} `json:"packages"`
+	Dependencies map[string]struct {
+		Version string `json:"version"`
+	} `json:"dependencies"`
+}
+
+// readPackageLock reads package-lock.json, or returns nil if there is
+// none.
+func readPackageLock() (*packageLock, error) {
+	in, err := os.ReadFile("package-lock.json")
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	lock := &packageLock{}
+	if err := json.Unmarshal(in, lock); err != nil {
+		return nil, fmt.Errorf("package-lock.json: %v", err)
+	}
+	return lock, nil
+}
+
+// npmInstalledVersion reads name's version from the lockfile, or from the
+// package installed in node_modules when there's no lockfile.
+func npmInstalledVersion(lock *packageLock, name string) (string, string, error) {
+	if lock != nil {
+		if p, ok := lock.Packages["node_modules/"+name]; ok && p.Version != "" {
+			return p.Version, "package-lock.json", nil
+		}
+		if d, ok := lock.Dependencies[name]; ok && d.Version != "" {
+			return d.Version, "package-lock.json", nil
+		}
+		return "", "", fmt.Errorf("%s is not in package-lock.json", name)
+	}
+
+	path := filepath.Join("node_modules", filepath.FromSlash(name), "package.json")
+	in, err := os.ReadFile(path)
+	if err != nil {
+		return "", "", fmt.Errorf("%s is not installed: no package-lock.json or %s", name, path)
+	}
+
+	pkg := struct {
+		Version string `json:"version"`
+	}{}
+	if err := json.Unmarshal(in, &pkg); err != nil {
+		return "", "", fmt.Errorf("%s: %v", path, err)
+	}
+
+	return pkg.Version, path, nil
+}
+
+// npmRepository asks the registry ($npm_config_registry, or npmjs.org)
+// where version of name is developed.
+func npmRepository(client *http.Client, name, version string) (string, error) {
+	registry := os.Getenv("npm_config_registry")
+	if registry == "" {
+		registry = defaultNpmRegistry
+	}
+	if !strings.HasSuffix(registry, "/") {
+		registry += "/"
+	}
+
+	// scoped names keep their @ but escape the slash
+	u := registry + strings.Replace(name, "/", "%2F", 1) + "/" + url.PathEscape(version)
+
+	req, err := http.NewRequestWithContext(context.Background(), "GET", u, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("npm registry: %s@%s: %s", name, version, resp.Status)
+	}
+
+	// repository is either a string or {"type": "git", "url": "..."}
+	meta := struct {
+		Repository json.RawMessage `json:"repository"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&meta); err != nil {
+		return "", fmt.Errorf("npm registry: %s: %v", name, err)
+	}
+
+	repoURL := ""
+	if err := json.Unmarshal(meta.Repository, &repoURL); err != nil {
+		obj := struct {
+			URL string `json:"url"`
+		}{}
+		json.Unmarshal(meta.Repository, &obj)
+		repoURL = obj.URL
+	}
+
+	repo, ok := githubRepoURL(repoURL)
+	if !ok {
+		return "", fmt.Errorf("%s's repository isn't on GitHub: %q", name, repoURL)
+	}
+
+	return repo, nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+)
+
+// runOutdated reports every dependency of the workspace with its current
+// and latest versions and how many releases of each kind lie between.
+func runOutdated() int {
+	deps, err := loadDependencies(true)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	provider, err := newProvider()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	results, err := checkDependencies(provider, deps)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if *jsonOutput {
+		out, _ := json.MarshalIndent(results, "", "  ")
+		fmt.Println(string(out))
+		return checkStatus(results)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "REPO\tSOURCE\tCURRENT\tLATEST\tMAJOR\tMINOR\tPATCH")
+	for _, r := range results {
+		if r.Error != "" {
+			fmt.Fprintf(w, "%s\t%s\t%s\terror: %s\t\t\t\n", r.Repo, r.Source, orDash(r.Current), r.Error)
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%d\t%d\n", r.Repo, r.Source, orDash(r.Current), orDash(r.Latest), r.Major, r.Minor, r.Patch)
+	}
+	w.Flush()
+
+	return checkStatus(results)
+}
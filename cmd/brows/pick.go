@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// pick asks which of items to use, defaulting to the first.
+func pick(prompt string, items []string) (int, error) {
+	fmt.Println(prompt)
+	for i, item := range items {
+		fmt.Printf("  %d) %s\n", i+1, item)
+	}
+	fmt.Print("Which one? [1] ")
+
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return 0, fmt.Errorf("nothing chosen")
+	}
+
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.Atoi(line)
+	if err != nil || n < 1 || n > len(items) {
+		return 0, fmt.Errorf("no choice %q", line)
+	}
+
+	return n - 1, nil
+}
+
+// chooseDependency finds the dependency named name, asking which one to
+// browse when no name is given.
+func chooseDependency(deps []dependency, name, source string) (dependency, error) {
+	if len(deps) == 0 {
+		return dependency{}, fmt.Errorf("no dependencies to browse in %s", source)
+	}
+
+	if name != "" {
+		for _, d := range deps {
+			if d.Name == name || (d.Repo != "" && d.Repo == name) {
+				return d, nil
+			}
+		}
+		return dependency{}, fmt.Errorf("%s is not a dependency brows can browse in %s", name, source)
+	}
+
+	items := make([]string, len(deps))
+	for i, d := range deps {
+		items[i] = fmt.Sprintf("%s %s", d.Name, d.Version)
+	}
+
+	i, err := pick(fmt.Sprintf("Dependencies in %s:", source), items)
+	if err != nil {
+		return dependency{}, err
+	}
+
+	return deps[i], nil
+}
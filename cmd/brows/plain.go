@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/charmbracelet/glamour"
+	"github.com/rubysolo/brows/pkg/releases"
+)
+
+// isTerminal reports whether f is attached to a terminal rather than a
+// pipe or file.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// newerReleases fetches the releases of owner/repo after version, up to
+// and including until if it's set, oldest first; version can also be a
+// constraint like "^1.4" the releases have to satisfy. --stable-only drops
+// prereleases and drafts.
+func newerReleases(provider releases.Provider, owner, repo, version, until string) ([]releases.Release, error) {
+	list, err := releases.Fetch(context.Background(), releases.Ref{Owner: owner, Repo: repo}, releases.WithProvider(provider))
+	if err != nil {
+		return nil, err
+	}
+
+	if c, ok := releases.ParseConstraint(version); ok {
+		list = releases.Satisfying(list, c)
+	} else {
+		r, err := releases.Range(version, until)
+		if err != nil {
+			return nil, err
+		}
+		list = r.Filter(list)
+	}
+	if *stableOnly {
+		list = releases.StableOnly(list)
+	}
+
+	return list, nil
+}
+
+type releaseJSON struct {
+	Tag        string     `json:"tag"`
+	Name       string     `json:"name"`
+	Date       *time.Time `json:"date"`
+	Body       string     `json:"body"`
+	Prerelease bool       `json:"prerelease"`
+	URL        string     `json:"url"`
+}
+
+// runJSON prints the releases after version, newest first, as a JSON
+// array.
+func runJSON(provider releases.Provider, owner, repo, version, until string) int {
+	list, err := newerReleases(provider, owner, repo, version, until)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(releasesJSON(list)); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	return 0
+}
+
+// releasesJSON converts list to its JSON form, newest first. Prerelease is
+// set for releases the forge marks as such and for semver prerelease tags.
+func releasesJSON(list []releases.Release) []releaseJSON {
+	out := make([]releaseJSON, 0, len(list))
+	for i := len(list) - 1; i >= 0; i-- {
+		r := list[i]
+
+		var date *time.Time
+		if !r.Published.IsZero() {
+			date = &r.Published
+		}
+
+		out = append(out, releaseJSON{
+			Tag:        r.Tag,
+			Name:       r.Name,
+			Date:       date,
+			Body:       r.Description,
+			Prerelease: r.Prerelease || releases.IsPrerelease(r.Tag),
+			URL:        r.URL,
+		})
+	}
+
+	return out
+}
+
+// runPlain prints the notes of every release after version, newest first,
+// instead of starting the TUI: rendered for a terminal with --plain,
+// rendered without colors when piped, or as the raw markdown with --raw.
+func runPlain(provider releases.Provider, owner, repo, version, until string) int {
+	list, err := newerReleases(provider, owner, repo, version, until)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	notes := releases.AggregateTitled(list)
+	if notes == "" {
+		fmt.Fprintf(os.Stderr, "No releases of %s/%s after %s.\n", owner, repo, version)
+		return 0
+	}
+
+	if *raw {
+		fmt.Print(notes)
+		return 0
+	}
+
+	style := "notty"
+	if isTerminal(os.Stdout) || *styleName != "" {
+		if style, err = glamourStyle(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+	}
+
+	out, err := glamour.Render(notes, style)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	fmt.Print(out)
+
+	return 0
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const pypiAPI = "https://pypi.org/pypi/"
+
+// pythonLocks are the files --from-pypi reads versions from, most precise
+// first.
+var pythonLocks = []string{"uv.lock", "poetry.lock", "requirements.txt"}
+
+// pypiNameRe matches the runs of separators PEP 503 treats as one.
+var pypiNameRe = regexp.MustCompile(`[-_.]+`)
+
+// normalizePypiName is name as PyPI compares it: lower case, with "-" for
+// every run of "-", "_" and ".".
+func normalizePypiName(name string) string {
+	return strings.ToLower(pypiNameRe.ReplaceAllString(name, "-"))
+}
+
+// pypiDependency finds the version of the Python package name in the
+// first of uv.lock, poetry.lock or requirements.txt that has it, and the
+// GitHub repository its PyPI metadata points at.
+func pypiDependency(name string) (dependency, error) {
+	version, source := "", ""
+	for _, path := range pythonLocks {
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+
+		var (
+			locked map[string]string
+			err    error
+		)
+		if path == "requirements.txt" {
+			locked, err = readRequirementsPins(path)
+		} else {
+			locked, err = readPythonLock(path)
+		}
+		if err != nil {
+			return dependency{}, fmt.Errorf("reading %s: %v", path, err)
+		}
+
+		if v, ok := locked[normalizePypiName(name)]; ok {
+			version, source = v, path
+			break
+		}
+	}
+	if version == "" {
+		return dependency{}, fmt.Errorf("%s is not pinned in %s", name, strings.Join(pythonLocks, ", "))
+	}
+
+	client, err := newHTTPClient(AppConfig)
+	if err != nil {
+		return dependency{}, err
+	}
+
+	repo, err := pypiRepository(client, name)
+	if err != nil {
+		return dependency{}, err
+	}
+
+	return dependency{Name: name, Repo: repo, Version: version, Source: source}, nil
+}
+
+// readPythonLock maps each package in a poetry.lock or uv.lock, by its
+// normalized name, to its locked version.
+func readPythonLock(path string) (map[string]string, error) {
+	packages, err := readLockPackages(path)
+	if err != nil {
+		return nil, err
+	}
+
+	locked := make(map[string]string, len(packages))
+	for name, version := range packages {
+		locked[normalizePypiName(name)] = version
+	}
+	return locked, nil
+}
+
+// readRequirementsPins maps each package a requirements.txt pins with ==
+// (or ===), by its normalized name, to its version. Ranges, includes,
+// options and editable installs pin nothing and are skipped.
+func readRequirementsPins(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	pins := map[string]string{}
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		// requests==2.31.0 ; python_version >= "3.8"
+		line, _, _ = strings.Cut(line, ";")
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "-") {
+			continue
+		}
+
+		name, version, ok := strings.Cut(line, "==")
+		if !ok {
+			continue
+		}
+		// requests[socks]==2.31.0
+		name, _, _ = strings.Cut(name, "[")
+		version = strings.TrimSpace(strings.TrimPrefix(version, "="))
+		if strings.ContainsAny(version, ",*<>!~ ") {
+			continue
+		}
+
+		pins[normalizePypiName(strings.TrimSpace(name))] = version
+	}
+
+	return pins, scanner.Err()
+}
+
+// readRequirements lists the packages a requirements.txt pins, with their
+// repositories looked up on PyPI.
+func readRequirements(path string) ([]dependency, error) {
+	pins, err := readRequirementsPins(path)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(pins))
+	for name := range pins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	deps := make([]dependency, len(names))
+	for i, name := range names {
+		deps[i] = dependency{Name: name, Version: pins[name], Source: filepath.Base(path)}
+	}
+
+	client, err := newHTTPClient(AppConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return resolveRepos(deps, func(d dependency) (string, error) {
+		return pypiRepository(client, d.Name)
+	}), nil
+}
+
+// pypiSourceKeys are the project_urls labels that name a package's
+// repository, tried ahead of the rest.
+var pypiSourceKeys = []string{"source", "source code", "repository", "code", "github", "homepage"}
+
+// pypiRepository asks PyPI where the package name is developed, trying
+// the project links that name its source first.
+func pypiRepository(client *http.Client, name string) (string, error) {
+	req, err := http.NewRequestWithContext(context.Background(), "GET", pypiAPI+url.PathEscape(name)+"/json", nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("pypi.org: %s: %s", name, resp.Status)
+	}
+
+	meta := struct {
+		Info struct {
+			ProjectURLs map[string]string `json:"project_urls"`
+			HomePage    string            `json:"home_page"`
+		} `json:"info"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&meta); err != nil {
+		return "", fmt.Errorf("pypi.org: %s: %v", name, err)
+	}
+
+	byLabel := map[string]string{}
+	labels := []string{}
+	for label, u := range meta.Info.ProjectURLs {
+		byLabel[strings.ToLower(label)] = u
+		labels = append(labels, strings.ToLower(label))
+	}
+	sort.Strings(labels)
+
+	links := []string{}
+	for _, label := range pypiSourceKeys {
+		links = append(links, byLabel[label])
+	}
+	for _, label := range labels {
+		links = append(links, byLabel[label])
+	}
+	links = append(links, meta.Info.HomePage)
+
+	if repo, ok := githubRepoIn(links...); ok {
+		return repo, nil
+	}
+
+	return "", fmt.Errorf("pypi.org doesn't link %s to a GitHub repository", name)
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// quota is the API rate limit the forge reported on its latest response,
+// for the footer.
+var quota rateLimit
+
+// rateLimit tracks the quota headers of GitHub (X-RateLimit-*) and GitLab
+// (RateLimit-*) responses. It's safe for concurrent use.
+type rateLimit struct {
+	mu        sync.Mutex
+	known     bool
+	remaining int
+	limit     int
+	reset     time.Time
+}
+
+// observe records the quota from h, if it carries one.
+func (r *rateLimit) observe(h http.Header) {
+	remaining, limit, reset, ok := parseRateLimit(h)
+	if !ok {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.known, r.remaining, r.limit, r.reset = true, remaining, limit, reset
+}
+
+// get returns the last reported quota; ok is false until one was seen.
+func (r *rateLimit) get() (remaining, limit int, reset time.Time, ok bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.remaining, r.limit, r.reset, r.known
+}
+
+func parseRateLimit(h http.Header) (remaining, limit int, reset time.Time, ok bool) {
+	for _, prefix := range []string{"X-Ratelimit-", "Ratelimit-"} {
+		rem, err := strconv.Atoi(h.Get(prefix + "Remaining"))
+		if err != nil {
+			continue
+		}
+		lim, _ := strconv.Atoi(h.Get(prefix + "Limit"))
+		if secs, err := strconv.ParseInt(h.Get(prefix+"Reset"), 10, 64); err == nil {
+			reset = time.Unix(secs, 0)
+		}
+		return rem, lim, reset, true
+	}
+	return 0, 0, time.Time{}, false
+}
+
+// rateLimited reports whether resp was refused because the primary quota
+// is spent. Waiting for that isn't worth it; it can be an hour away.
+func rateLimited(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return false
+	}
+	remaining, _, _, ok := parseRateLimit(resp.Header)
+	return ok && remaining == 0
+}
+
+// stripRateLimit drops the quota headers from a response served from the
+// cache in place of a rate-limited one. go-github refuses to send anything
+// more until the reset once it has seen the quota run out, which would
+// keep every later request from reaching the cache too.
+func stripRateLimit(h http.Header) {
+	for name := range h {
+		if strings.HasPrefix(name, "X-Ratelimit-") || strings.HasPrefix(name, "Ratelimit-") {
+			delete(h, name)
+		}
+	}
+}
+
+// retryBackoff is how long to wait before each retry of a request that hit
+// a secondary rate limit without saying when to come back. The retries
+// share the client's timeout, so they're kept well inside the default.
+var retryBackoff = []time.Duration{time.Second, 3 * time.Second, 8 * time.Second}
+
+// maxRetryAfter is the longest Retry-After worth waiting for.
+const maxRetryAfter = 15 * time.Second
+
+// retryTransport retries requests refused by a secondary rate limit, the
+// kind GitHub imposes on bursts of requests, waiting as long as the
+// response asks or backing off. It also records the quota of every
+// response it sees.
+type retryTransport struct {
+	base http.RoundTripper
+}
+
+func (t retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		quota.observe(resp.Header)
+
+		wait, ok := secondaryLimit(resp, attempt)
+		if !ok || (req.Body != nil && req.GetBody == nil) {
+			return resp, nil
+		}
+		resp.Body.Close()
+
+		slog.Debug("secondary rate limit, retrying", "url", req.URL.String(), "wait", wait, "attempt", attempt+1)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// secondaryLimit reports whether resp hit a secondary rate limit that's
+// worth retrying, and how long to wait first.
+func secondaryLimit(resp *http.Response, attempt int) (time.Duration, bool) {
+	if attempt >= len(retryBackoff) || rateLimited(resp) {
+		return 0, false
+	}
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		wait := time.Duration(secs) * time.Second
+		return wait, wait <= maxRetryAfter
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return retryBackoff[attempt], true
+	}
+
+	// a 403 is usually a permissions problem; only the message tells them
+	// apart
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil || !strings.Contains(strings.ToLower(string(body)), "secondary rate limit") {
+		return 0, false
+	}
+	return retryBackoff[attempt], true
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bufio"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/masterminds/semver"
+)
+
+// resolveRepos fills in the repository of every dependency that doesn't
+// know it yet, looking a few up at a time, and drops the ones resolve
+// can't place on GitHub.
+func resolveRepos(deps []dependency, resolve func(d dependency) (string, error)) []dependency {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, checkConcurrency)
+
+	for i := range deps {
+		if deps[i].Repo != "" {
+			continue
+		}
+
+		wg.Add(1)
+		go func(d *dependency) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			repo, err := resolve(*d)
+			if err != nil {
+				slog.Debug("skipping dependency", "name", d.Name, "source", d.Source, "err", err)
+				return
+			}
+			d.Repo = repo
+		}(&deps[i])
+	}
+	wg.Wait()
+
+	resolved := deps[:0]
+	for _, d := range deps {
+		if d.Repo != "" {
+			resolved = append(resolved, d)
+		}
+	}
+	return resolved
+}
+
+// githubRepoIn is the first of the links a registry's metadata lists that
+// points at a GitHub repository, as owner/repo.
+func githubRepoIn(links ...string) (string, bool) {
+	for _, u := range links {
+		if !strings.Contains(u, "github.com") {
+			continue
+		}
+		if repo, ok := githubRepoURL(u); ok {
+			return repo, true
+		}
+	}
+	return "", false
+}
+
+// readLockPackages maps each package in a lockfile made of [[package]]
+// tables with name and version keys, as Cargo.lock, poetry.lock and
+// uv.lock are, to its version. A package locked at several versions maps
+// to the newest.
+func readLockPackages(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	locked := map[string]string{}
+	name := ""
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case line == "[[package]]":
+			name = ""
+		case strings.HasPrefix(line, "name = "):
+			name = strings.Trim(strings.TrimPrefix(line, "name = "), `"`)
+		case strings.HasPrefix(line, "version = ") && name != "":
+			version := strings.Trim(strings.TrimPrefix(line, "version = "), `"`)
+			if newerVersion(version, locked[name]) {
+				locked[name] = version
+			}
+		}
+	}
+
+	return locked, scanner.Err()
+}
+
+// newerVersion reports whether a is a newer version than b, or b is empty.
+func newerVersion(a, b string) bool {
+	if b == "" {
+		return true
+	}
+	va, errA := semver.NewVersion(a)
+	vb, errB := semver.NewVersion(b)
+	return errA == nil && errB == nil && va.GreaterThan(vb)
+}
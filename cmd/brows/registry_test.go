@@ -0,0 +1,60 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestReadLockPackages(t *testing.T) {
+	tests := []struct {
+		name string
+		lock string
+		want map[string]string
+	}{
+		{
+			name: "Cargo.lock",
+			lock: "version = 3\n\n[[package]]\nname = \"serde\"\nversion = \"1.0.152\"\nsource = \"registry+https://github.com/rust-lang/crates.io-index\"\n\n[[package]]\nname = \"tokio\"\nversion = \"1.24.1\"\n",
+			want: map[string]string{"serde": "1.0.152", "tokio": "1.24.1"},
+		},
+		{
+			name: "several versions of one package",
+			lock: "[[package]]\nname = \"syn\"\nversion = \"1.0.109\"\n\n[[package]]\nname = \"syn\"\nversion = \"2.0.1\"\n\n[[package]]\nname = \"syn\"\nversion = \"1.0.0\"\n",
+			want: map[string]string{"syn": "2.0.1"},
+		},
+		{
+			name: "poetry.lock",
+			lock: "[[package]]\nname = \"requests\"\nversion = \"2.28.2\"\ndescription = \"Python HTTP for Humans.\"\n\n[metadata]\nlock-version = \"2.0\"\n",
+			want: map[string]string{"requests": "2.28.2"},
+		},
+		{
+			name: "empty",
+			lock: "",
+			want: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "test.lock")
+			if err := os.WriteFile(path, []byte(tt.lock), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := readLockPackages(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("readLockPackages() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadLockPackagesMissing(t *testing.T) {
+	if _, err := readLockPackages(filepath.Join(t.TempDir(), "Cargo.lock")); !os.IsNotExist(err) {
+		t.Errorf("readLockPackages() error = %v, want not exist", err)
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"net/url"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// scpRemoteRe matches the scp-like remotes git uses for ssh, like
+// git@github.com:owner/repo.git.
+var scpRemoteRe = regexp.MustCompile(`^([\w.-]+)@([\w.-]+):([^/].*)$`)
+
+// sshRemote rewrites an scp-like remote as an ssh:// URL; anything else is
+// returned as it is.
+func sshRemote(arg string) string {
+	if m := scpRemoteRe.FindStringSubmatch(arg); m != nil {
+		return "ssh://" + m[1] + "@" + m[2] + "/" + m[3]
+	}
+	return arg
+}
+
+// originRemote is the URL of the current directory's origin remote, parsed,
+// along with it as git reports it.
+func originRemote() (*url.URL, string, bool) {
+	out, err := exec.Command("git", "remote", "get-url", "origin").Output()
+	if err != nil {
+		return nil, "", false
+	}
+	remote := strings.TrimSpace(string(out))
+
+	u, err := url.Parse(sshRemote(remote))
+	if err != nil || u.Host == "" {
+		return nil, "", false
+	}
+	return u, remote, true
+}
+
+// isOrigin reports whether owner/repo is what the current directory's git
+// checkout was cloned from.
+func isOrigin(owner, repo string) bool {
+	u, _, ok := originRemote()
+	if !ok {
+		return false
+	}
+	path := strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git")
+	return strings.EqualFold(path, owner+"/"+repo)
+}
+
+// describeVersion is the latest tag reachable from the checkout's HEAD,
+// per git describe, when it's a version: what the checkout is at, or has
+// moved on from.
+func describeVersion() (string, bool) {
+	out, err := exec.Command("git", "describe", "--tags", "--abbrev=0").Output()
+	if err != nil {
+		return "", false
+	}
+	tag := strings.TrimSpace(string(out))
+	return tag, tag != "" && isVersionArg(tag)
+}
+
+// originRepo is the repository the current directory's git checkout was
+// cloned from, as owner/repo, when its origin remote is on a forge brows
+// knows: github.com, a GitLab, a Gitea, bitbucket.org, or the configured
+// GitHub Enterprise Server.
+func originRepo() (string, bool) {
+	u, remote, ok := originRemote()
+	if !ok {
+		return "", false
+	}
+	host := u.Hostname()
+
+	known := host == "github.com" || host == "bitbucket.org" || giteaHost(host) ||
+		strings.Contains(host, "gitlab") || *providerName == "gitlab"
+	if b, err := url.Parse(githubBaseURL(AppConfig)); err == nil && b.Hostname() == host {
+		known = true
+	}
+	if b, err := url.Parse(AppConfig.GitLabBaseURL); err == nil && b.Hostname() == host {
+		known = true
+		*providerName = "gitlab"
+	}
+	if !known {
+		return "", false
+	}
+
+	repo, err := projectURL(remote)
+	if err != nil {
+		return "", false
+	}
+	return repo, repo != remote && strings.Contains(repo, "/")
+}
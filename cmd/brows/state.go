@@ -0,0 +1,75 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/rubysolo/brows/pkg/store"
+)
+
+func runState(args []string) int {
+	switch {
+	case len(args) >= 1 && len(args) <= 2 && args[0] == "export":
+		return runStateExport(args[1:])
+	case len(args) == 2 && args[0] == "import":
+		return runStateImport(args[1])
+	}
+
+	fmt.Println("Usage:")
+	fmt.Println("  brows state export [file]")
+	fmt.Println("  brows state import file")
+	return 1
+}
+
+// runStateExport writes review markers, history, watchlists and pins as
+// JSON, to file or stdout.
+func runStateExport(args []string) int {
+	snap, err := AppStore.Export(store.Portable)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	out, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if len(args) == 0 {
+		fmt.Println(string(out))
+		return 0
+	}
+
+	if err := os.WriteFile(args[0], append(out, '\n'), 0600); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	fmt.Printf("Exported state to %s\n", args[0])
+	return 0
+}
+
+func runStateImport(path string) int {
+	in, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	var snap store.Snapshot
+	if err := json.Unmarshal(in, &snap); err != nil {
+		fmt.Fprintf(os.Stderr, "%s is not a brows state export: %v\n", path, err)
+		return 1
+	}
+
+	count, err := AppStore.Import(snap, store.Portable)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	fmt.Printf("Imported %d entries from %s\n", count, path)
+	return 0
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// runStatus checks every dependency of the workspace. With --short it
+// prints a single line, e.g. "3 deps behind (1 major)", small enough for a
+// tmux status bar or shell prompt. So that it's quick, it only reads the
+// manifests that name repositories, without asking registries about
+// packages.
+func runStatus() int {
+	deps, err := loadDependencies(!*short)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	provider, err := newProvider()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	results, err := checkDependencies(provider, deps)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	switch {
+	case *short:
+		fmt.Println(shortStatus(results))
+	case *jsonOutput:
+		out, _ := json.MarshalIndent(results, "", "  ")
+		fmt.Println(string(out))
+	default:
+		printChecks(results)
+	}
+
+	return checkStatus(results)
+}
+
+func shortStatus(results []checkResult) string {
+	behind, major, failed := 0, 0, 0
+	for _, r := range results {
+		switch {
+		case r.Error != "":
+			failed++
+		case r.Current != "" && !r.UpToDate():
+			behind++
+			if r.Major > 0 {
+				major++
+			}
+		}
+	}
+
+	line := "deps up to date"
+	if behind > 0 {
+		line = fmt.Sprintf("%d deps behind", behind)
+		if major > 0 {
+			line += fmt.Sprintf(" (%d major)", major)
+		}
+	}
+	if failed > 0 {
+		line += fmt.Sprintf(", %d failed", failed)
+	}
+
+	return line
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/glamour/ansi"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// glamourStyle picks the style notes are rendered in. --style or the style
+// config key names a glamour style ("notty", "ascii", "dracula", ...) or a
+// JSON stylesheet; otherwise --theme or the theme config key chooses
+// "dark", "light" or "auto" (the default), which asks the terminal for its
+// background color.
+func glamourStyle() (string, error) {
+	style := *styleName
+	if style == "" {
+		style = AppConfig.Style
+	}
+	if style != "" {
+		return checkStyle(os.ExpandEnv(style))
+	}
+
+	theme := *themeName
+	if theme == "" {
+		theme = AppConfig.Theme
+	}
+
+	switch theme {
+	case "dark", "light":
+		return theme, nil
+	case "", "auto":
+		if lipgloss.HasDarkBackground() {
+			return "dark", nil
+		}
+		return "light", nil
+	default:
+		return "", fmt.Errorf("unknown theme %q (expected dark, light or auto)", theme)
+	}
+}
+
+// checkStyle makes sure style is a built-in glamour style or a readable
+// stylesheet, so a typo fails at startup rather than on every render.
+func checkStyle(style string) (string, error) {
+	if _, ok := glamour.DefaultStyles[style]; ok {
+		return style, nil
+	}
+
+	in, err := os.ReadFile(style)
+	if err != nil {
+		return "", fmt.Errorf("style %q is neither a built-in style nor a readable file: %v", style, err)
+	}
+
+	var config ansi.StyleConfig
+	if err := json.Unmarshal(in, &config); err != nil {
+		return "", fmt.Errorf("style %s: %v", style, err)
+	}
+
+	return style, nil
+}
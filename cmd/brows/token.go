@@ -0,0 +1,166 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultTokenSources is the order token sources are tried in unless the
+// token_sources config key says otherwise. GITHUB_OAUTH_TOKEN stays first
+// so existing setups keep working.
+var defaultTokenSources = []string{"GITHUB_OAUTH_TOKEN", "GITHUB_TOKEN", "GH_TOKEN", "config", "command", "keychain", "gh", "hub"}
+
+// lookupToken reads a single source. "config" is the token key of the
+// config file, "command" runs token_command, "keychain" is the OS keychain
+// entry written by brows, and "gh" and "hub" borrow the logins of those
+// CLIs; any other name is an environment variable.
+func lookupToken(source string, cfg *Config) (string, error) {
+	switch source {
+	case "config":
+		return cfg.Token, nil
+	case "command":
+		return commandToken(cfg.TokenCommand)
+	case "keychain":
+		return keychainGet(githubHost(cfg))
+	case "gh":
+		return ghToken(githubHost(cfg))
+	case "hub":
+		return hubToken(githubHost(cfg))
+	default:
+		return os.Getenv(source), nil
+	}
+}
+
+// envSource reports whether source names an environment variable rather
+// than one of the sources lookupToken knows by name.
+func envSource(source string) bool {
+	switch source {
+	case "config", "command", "keychain", "gh", "hub":
+		return false
+	}
+	return true
+}
+
+// githubHost is the host name tokens are kept under by other tools:
+// github.com, or the Enterprise Server brows is pointed at, by --base-url,
+// a project URL or the config, the same one the API client talks to.
+func githubHost(cfg *Config) string {
+	if u, err := url.Parse(githubBaseURL(cfg)); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return "github.com"
+}
+
+// commandToken runs the user's token command through the shell and reads
+// the token from its output, e.g. "op read op://Private/GitHub/token".
+func commandToken(command string) (string, error) {
+	if command == "" {
+		return "", nil
+	}
+
+	cmd := exec.Command("sh", "-c", command)
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	}
+	cmd.Stderr = os.Stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("token_command: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// ghToken asks the GitHub CLI for the token it's logged in with. Not
+// having gh, or not being logged in, isn't an error.
+func ghToken(host string) (string, error) {
+	if !hasCommand("gh") {
+		return "", nil
+	}
+
+	out, err := exec.Command("gh", "auth", "token", "--hostname", host).Output()
+	if err != nil {
+		return "", nil
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// hubToken reads the token hub keeps in its config file, $HUB_CONFIG or
+// ~/.config/hub.
+func hubToken(host string) (string, error) {
+	path := os.Getenv("HUB_CONFIG")
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", nil
+		}
+		path = filepath.Join(home, ".config", "hub")
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	hosts := map[string][]struct {
+		Token string `yaml:"oauth_token"`
+	}{}
+	if err := yaml.Unmarshal(data, &hosts); err != nil {
+		return "", fmt.Errorf("%s: %w", path, err)
+	}
+
+	for _, entry := range hosts[host] {
+		if entry.Token != "" {
+			return entry.Token, nil
+		}
+	}
+	return "", nil
+}
+
+// errNoToken is returned by findToken when none of the sources has a
+// token, as opposed to one of them failing.
+var errNoToken = errors.New("no GitHub token found")
+
+// findToken returns the first non-empty token in sources, along with the
+// name of the source it came from. A source that fails, like a
+// token_command exiting non-zero, is an error rather than skipped; only
+// a keychain brows can't reach counts as not set.
+func findToken(sources []string, cfg *Config) (string, string, error) {
+	for _, source := range sources {
+		token, err := lookupToken(source, cfg)
+		token = strings.TrimSpace(token)
+		slog.Debug("token source checked", "source", source, "found", token != "", "err", err)
+
+		if err != nil && !errors.Is(err, errKeychainUnavailable) {
+			return "", "", fmt.Errorf("reading the GitHub token from %s: %w", source, err)
+		}
+		if token != "" {
+			return token, source, nil
+		}
+	}
+
+	return "", "", fmt.Errorf("%w (checked %s)", errNoToken, strings.Join(sources, ", "))
+}
+
+// tokenSources resolves the precedence order: --token-source wins, then
+// the config file, then the defaults.
+func tokenSources(cfg *Config) []string {
+	if *tokenSource != "" {
+		return []string{*tokenSource}
+	}
+	if len(cfg.TokenSources) > 0 {
+		return cfg.TokenSources
+	}
+	return defaultTokenSources
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/rubysolo/brows/pkg/releases"
+	"github.com/rubysolo/brows/pkg/store"
+)
+
+// watched is the watchlist entry kept for each repository: the version
+// new releases are counted from.
+type watched struct {
+	Version string    `json:"version"`
+	Added   time.Time `json:"added"`
+}
+
+func runWatch(args []string) int {
+	switch {
+	case len(args) >= 2 && args[0] == "add":
+		return runWatchAdd(args[1:])
+	case len(args) >= 2 && (args[0] == "remove" || args[0] == "rm"):
+		return runWatchRemove(args[1:])
+	case len(args) == 1 && args[0] == "list":
+		return runWatchList()
+	case len(args) == 1 && args[0] == "check":
+		return runWatchCheck()
+	}
+
+	fmt.Println("Usage:")
+	fmt.Println("  brows watch add organization/repo[@version]...")
+	fmt.Println("  brows watch remove organization/repo...")
+	fmt.Println("  brows watch list")
+	fmt.Println("  brows watch check [--short | --json]")
+	return 1
+}
+
+// runWatchAdd adds repositories to the watchlist, or moves them on to a new
+// version. Without a version, the latest release is the one new releases
+// are counted from.
+func runWatchAdd(args []string) int {
+	var provider releases.Provider
+
+	for _, arg := range args {
+		name, version, _ := strings.Cut(arg, "@")
+		owner, repo, err := splitRepo(name)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		key := owner + "/" + repo
+
+		if version == "" {
+			if provider == nil {
+				if provider, err = newProvider(); err != nil {
+					fmt.Fprintln(os.Stderr, err)
+					return 1
+				}
+			}
+			list, err := releases.Fetch(context.Background(), releases.Ref{Owner: owner, Repo: repo}, releases.WithProvider(provider))
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %v\n", key, err)
+				return 1
+			}
+			behind, _ := releases.Compare("0.0.0", list)
+			if behind.Latest == "" {
+				fmt.Fprintf(os.Stderr, "%s has no releases yet; give the version to watch from: %s@0.0.0\n", key, key)
+				return 1
+			}
+			version = behind.Latest
+		}
+
+		if !isVersionArg(version) {
+			fmt.Fprintf(os.Stderr, "%s is not a version\n", version)
+			return 1
+		}
+
+		if err := AppStore.Put(store.BucketWatch, key, watched{Version: version, Added: time.Now()}); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		fmt.Printf("Watching %s for releases after %s\n", key, version)
+	}
+
+	return 0
+}
+
+func runWatchRemove(args []string) int {
+	for _, name := range args {
+		owner, repo, err := splitRepo(name)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		key := owner + "/" + repo
+
+		if ok, _ := AppStore.Get(store.BucketWatch, key, &watched{}); !ok {
+			fmt.Fprintf(os.Stderr, "%s isn't watched\n", key)
+			return 1
+		}
+		if err := AppStore.Delete(store.BucketWatch, key); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		fmt.Printf("Stopped watching %s\n", key)
+	}
+
+	return 0
+}
+
+// watchlist reads the watched repositories as owner/repo@version
+// arguments, in name order.
+func watchlist() []string {
+	args := []string{}
+	for _, repo := range AppStore.Keys(store.BucketWatch, "") {
+		w := watched{}
+		if ok, err := AppStore.Get(store.BucketWatch, repo, &w); ok && err == nil {
+			args = append(args, repo+"@"+w.Version)
+		}
+	}
+	return args
+}
+
+func runWatchList() int {
+	args := watchlist()
+	if len(args) == 0 {
+		fmt.Println("Nothing watched yet; add a repository with brows watch add organization/repo")
+		return 0
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "REPO\tVERSION")
+	for _, arg := range args {
+		repo, version, _ := strings.Cut(arg, "@")
+		fmt.Fprintf(w, "%s\t%s\n", repo, version)
+	}
+	w.Flush()
+
+	return 0
+}
+
+// runWatchCheck reports which watched repositories have released since
+// their version. The exit status is 0 when none has, 1 when some have and
+// 2 when a check failed, so cron jobs and prompts can act on it.
+func runWatchCheck() int {
+	args := watchlist()
+	if len(args) == 0 {
+		switch {
+		case *short:
+			fmt.Println(watchSummary(nil))
+		case *jsonOutput:
+			fmt.Println("[]")
+		default:
+			fmt.Println("Nothing watched yet; add a repository with brows watch add organization/repo")
+		}
+		return 0
+	}
+
+	provider, err := newProvider()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	results := checkAll(provider, args, nil)
+
+	switch {
+	case *short:
+		fmt.Println(watchSummary(results))
+	case *jsonOutput:
+		out, _ := json.MarshalIndent(results, "", "  ")
+		fmt.Println(string(out))
+	default:
+		printChecks(results)
+		fmt.Println()
+		fmt.Println(watchSummary(results))
+	}
+
+	if checkStatus(results) != 0 {
+		return 2
+	}
+	for _, r := range results {
+		if !r.UpToDate() {
+			return 1
+		}
+	}
+	return 0
+}
+
+// watchSummary is one line naming the repositories with new releases, e.g.
+// "2 watched repos have new releases: cli/cli v2.40.0, junegunn/fzf 0.45.0".
+func watchSummary(results []checkResult) string {
+	updated, failed := []string{}, 0
+	for _, r := range results {
+		switch {
+		case r.Error != "":
+			failed++
+		case !r.UpToDate():
+			updated = append(updated, r.Repo+" "+r.Latest)
+		}
+	}
+
+	line := "no new releases"
+	switch len(updated) {
+	case 0:
+	case 1:
+		line = "1 watched repo has new releases: " + updated[0]
+	default:
+		line = fmt.Sprintf("%d watched repos have new releases: %s", len(updated), strings.Join(updated, ", "))
+	}
+	if failed > 0 {
+		line += fmt.Sprintf(", %d failed", failed)
+	}
+
+	return line
+}
@@ -0,0 +1,205 @@
+package releases
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v48/github"
+	"github.com/masterminds/semver"
+)
+
+// Ref names a repository as owner/repo.
+type Ref struct {
+	Owner string
+	Repo  string
+}
+
+// ParseRef parses an "owner/repo" string.
+func ParseRef(s string) (Ref, error) {
+	parts := strings.Split(s, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return Ref{}, fmt.Errorf("invalid repository %q, expected owner/repo", s)
+	}
+
+	return Ref{Owner: parts[0], Repo: parts[1]}, nil
+}
+
+func (r Ref) String() string {
+	return fmt.Sprintf("%s/%s", r.Owner, r.Repo)
+}
+
+type options struct {
+	provider Provider
+}
+
+// Option configures Fetch.
+type Option func(*options)
+
+// WithProvider fetches from p instead of the unauthenticated GitHub API.
+func WithProvider(p Provider) Option {
+	return func(o *options) {
+		o.provider = p
+	}
+}
+
+// Fetch returns the releases of ref whose tags are semantic versions,
+// sorted oldest first.
+func Fetch(ctx context.Context, ref Ref, opts ...Option) ([]Release, error) {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.provider == nil {
+		o.provider = NewGitHubProvider(github.NewClient(http.DefaultClient))
+	}
+
+	list, err := o.provider.ListReleases(ctx, ref.Owner, ref.Repo)
+	if err != nil {
+		return nil, err
+	}
+
+	return Sort(list), nil
+}
+
+// Sort returns the releases whose tags parse as semantic versions, oldest
+// first.
+func Sort(list []Release) []Release {
+	type versioned struct {
+		v *semver.Version
+		r Release
+	}
+
+	parsed := make([]versioned, 0, len(list))
+	for _, r := range list {
+		v, err := semver.NewVersion(r.Tag)
+		if err != nil {
+			continue
+		}
+		parsed = append(parsed, versioned{v, r})
+	}
+
+	sort.SliceStable(parsed, func(i, j int) bool {
+		return parsed[i].v.LessThan(parsed[j].v)
+	})
+
+	out := make([]Release, len(parsed))
+	for i, p := range parsed {
+		out[i] = p.r
+	}
+
+	return out
+}
+
+// VersionRange selects the releases after From up to and including To.
+// A nil bound is open.
+type VersionRange struct {
+	From *semver.Version
+	To   *semver.Version
+}
+
+// Range builds a VersionRange; either bound may be empty.
+func Range(from, to string) (VersionRange, error) {
+	var (
+		r   VersionRange
+		err error
+	)
+
+	if from != "" {
+		if r.From, err = semver.NewVersion(from); err != nil {
+			return r, fmt.Errorf("invalid range start %q: %v", from, err)
+		}
+	}
+
+	if to != "" {
+		if r.To, err = semver.NewVersion(to); err != nil {
+			return r, fmt.Errorf("invalid range end %q: %v", to, err)
+		}
+	}
+
+	return r, nil
+}
+
+// Contains reports whether v falls within the range.
+func (r VersionRange) Contains(v *semver.Version) bool {
+	if r.From != nil && !v.GreaterThan(r.From) {
+		return false
+	}
+	if r.To != nil && v.GreaterThan(r.To) {
+		return false
+	}
+	return true
+}
+
+// Filter returns the releases whose tags fall within the range, in their
+// original order. Tags that are not semantic versions are dropped.
+func (r VersionRange) Filter(list []Release) []Release {
+	out := []Release{}
+	for _, rel := range list {
+		v, err := semver.NewVersion(rel.Tag)
+		if err != nil || !r.Contains(v) {
+			continue
+		}
+		out = append(out, rel)
+	}
+	return out
+}
+
+// Satisfying returns the releases whose tags satisfy c, in their original
+// order. Tags that are not semantic versions are dropped.
+func Satisfying(list []Release, c *semver.Constraints) []Release {
+	out := []Release{}
+	for _, rel := range list {
+		v, err := semver.NewVersion(rel.Tag)
+		if err != nil || !c.Check(v) {
+			continue
+		}
+		out = append(out, rel)
+	}
+	return out
+}
+
+// StableOnly returns the releases in list that are Stable, in their
+// original order.
+func StableOnly(list []Release) []Release {
+	out := []Release{}
+	for _, rel := range list {
+		if rel.Stable() {
+			out = append(out, rel)
+		}
+	}
+	return out
+}
+
+// Aggregate concatenates the notes of list, newest first, into a single
+// markdown document with a heading per tag.
+func Aggregate(list []Release) string {
+	return aggregate(list, func(r Release) string { return r.Tag })
+}
+
+// AggregateTitled is Aggregate with each heading the release's Title: its
+// tag followed by its name, when it has one.
+func AggregateTitled(list []Release) string {
+	return aggregate(list, Release.Title)
+}
+
+func aggregate(list []Release, heading func(Release) string) string {
+	var b strings.Builder
+
+	for i := len(list) - 1; i >= 0; i-- {
+		r := list[i]
+		fmt.Fprintf(&b, "# %s\n\n", heading(r))
+
+		body := strings.TrimSpace(r.Description)
+		if body == "" {
+			body = "_No release notes._"
+		}
+		b.WriteString(body)
+		b.WriteString("\n\n")
+	}
+
+	return b.String()
+}
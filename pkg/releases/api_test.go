@@ -0,0 +1,146 @@
+package releases_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rubysolo/brows/pkg/releases"
+	"github.com/rubysolo/brows/pkg/releases/fake"
+)
+
+func tags(list []releases.Release) []string {
+	out := []string{}
+	for _, r := range list {
+		out = append(out, r.Tag)
+	}
+	return out
+}
+
+func TestFetch(t *testing.T) {
+	p := fake.New().Add("acme", "tool",
+		releases.Release{Tag: "v1.10.0"},
+		releases.Release{Tag: "nightly"},
+		releases.Release{Tag: "v1.2.0"},
+		releases.Release{Tag: "v1.9.1"},
+		releases.Release{Tag: "v2.0.0-rc.1"},
+	)
+
+	tests := []struct {
+		name    string
+		ref     releases.Ref
+		want    []string
+		wantErr bool
+	}{
+		{"sorted oldest first, non-semver dropped", releases.Ref{Owner: "acme", Repo: "tool"}, []string{"v1.2.0", "v1.9.1", "v1.10.0", "v2.0.0-rc.1"}, false},
+		{"unknown repository", releases.Ref{Owner: "acme", Repo: "missing"}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := releases.Fetch(context.Background(), tt.ref, releases.WithProvider(p))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Fetch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(tags(got), tt.want) {
+				t.Errorf("Fetch() = %v, want %v", tags(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestFetchProviderError(t *testing.T) {
+	boom := errors.New("boom")
+	p := fake.New().Add("acme", "tool", releases.Release{Tag: "v1.0.0"})
+	p.Err = boom
+
+	if _, err := releases.Fetch(context.Background(), releases.Ref{Owner: "acme", Repo: "tool"}, releases.WithProvider(p)); !errors.Is(err, boom) {
+		t.Errorf("Fetch() error = %v, want %v", err, boom)
+	}
+}
+
+func TestRange(t *testing.T) {
+	list := []releases.Release{{Tag: "v1.0.0"}, {Tag: "v1.1.0"}, {Tag: "v1.2.0"}, {Tag: "v2.0.0"}, {Tag: "latest"}}
+
+	tests := []struct {
+		name     string
+		from, to string
+		want     []string
+		wantErr  bool
+	}{
+		{"open", "", "", []string{"v1.0.0", "v1.1.0", "v1.2.0", "v2.0.0"}, false},
+		{"start is exclusive", "1.1.0", "", []string{"v1.2.0", "v2.0.0"}, false},
+		{"end is inclusive", "", "v1.1.0", []string{"v1.0.0", "v1.1.0"}, false},
+		{"both bounds", "v1.0.0", "v1.2.0", []string{"v1.1.0", "v1.2.0"}, false},
+		{"empty", "v2.0.0", "", []string{}, false},
+		{"invalid start", "one", "", nil, true},
+		{"invalid end", "", "two", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := releases.Range(tt.from, tt.to)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Range() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := tags(r.Filter(list)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Filter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStableOnly(t *testing.T) {
+	tests := []struct {
+		name string
+		list []releases.Release
+		want []string
+	}{
+		{"all stable", []releases.Release{{Tag: "v1.0.0"}, {Tag: "v1.1.0"}}, []string{"v1.0.0", "v1.1.0"}},
+		{"semver prerelease", []releases.Release{{Tag: "v1.0.0"}, {Tag: "v1.1.0-beta.1"}}, []string{"v1.0.0"}},
+		{"marked prerelease", []releases.Release{{Tag: "v1.0.0", Prerelease: true}, {Tag: "v1.1.0"}}, []string{"v1.1.0"}},
+		{"draft", []releases.Release{{Tag: "v1.0.0"}, {Tag: "v1.1.0", Draft: true}}, []string{"v1.0.0"}},
+		{"none", nil, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tags(releases.StableOnly(tt.list)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("StableOnly() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	list := []releases.Release{
+		{Tag: "v1.0.0", Name: "First", Description: "  Initial release.\n"},
+		{Tag: "v1.1.0", Name: "v1.1.0"},
+	}
+
+	tests := []struct {
+		name      string
+		aggregate func([]releases.Release) string
+		want      string
+	}{
+		{"by tag, newest first", releases.Aggregate, "# v1.1.0\n\n_No release notes._\n\n# v1.0.0\n\nInitial release.\n\n"},
+		{"titled", releases.AggregateTitled, "# v1.1.0\n\n_No release notes._\n\n# v1.0.0 – First\n\nInitial release.\n\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.aggregate(list); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := releases.Aggregate(nil); strings.TrimSpace(got) != "" {
+		t.Errorf("Aggregate(nil) = %q, want empty", got)
+	}
+}
@@ -0,0 +1,112 @@
+package releases
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/masterminds/semver"
+)
+
+// Behind summarizes how far a version is from the newest release.
+type Behind struct {
+	Current string `json:"current"`
+	Latest  string `json:"latest"`
+
+	// Releases counts every release newer than Current; Major, Minor and
+	// Patch count the stable ones of each kind among them.
+	Releases int `json:"releases"`
+	Major    int `json:"major"`
+	Minor    int `json:"minor"`
+	Patch    int `json:"patch"`
+
+	// Lag is how much older Current is than Latest, when both publish
+	// dates are known. A Current that wasn't released itself is dated by
+	// the newest release before it, or failing that the oldest after it.
+	Lag time.Duration `json:"lag,omitempty"`
+}
+
+// UpToDate reports whether there's nothing newer than Current.
+func (b Behind) UpToDate() bool {
+	return b.Releases == 0
+}
+
+func (b Behind) String() string {
+	if b.UpToDate() {
+		return "up to date"
+	}
+	return fmt.Sprintf("%d releases behind (%d major, %d minor, %d patch)", b.Releases, b.Major, b.Minor, b.Patch)
+}
+
+// Compare measures current against list, which needn't be sorted. Latest
+// is the newest stable release, or the newest release if none are stable.
+func Compare(current string, list []Release) (Behind, error) {
+	cur, err := semver.NewVersion(current)
+	if err != nil {
+		return Behind{}, fmt.Errorf("invalid version %q: %v", current, err)
+	}
+
+	b := Behind{Current: current}
+
+	var latest, latestStable *semver.Version
+	published := map[*semver.Version]time.Time{}
+	// the releases closest to current at or below it, and above it
+	var below, above *semver.Version
+	for _, r := range list {
+		v, err := semver.NewVersion(r.Tag)
+		if err != nil {
+			continue
+		}
+		published[v] = r.Published
+		switch {
+		case r.Published.IsZero():
+			// undated, so no help dating current
+		case v.GreaterThan(cur):
+			if above == nil || v.LessThan(above) {
+				above = v
+			}
+		case below == nil || v.GreaterThan(below):
+			below = v
+		}
+
+		if latest == nil || v.GreaterThan(latest) {
+			latest = v
+		}
+		if v.Prerelease() == "" && (latestStable == nil || v.GreaterThan(latestStable)) {
+			latestStable = v
+		}
+
+		if !v.GreaterThan(cur) {
+			continue
+		}
+
+		b.Releases++
+		switch {
+		case IsMajor(v):
+			b.Major++
+		case IsMinor(v):
+			b.Minor++
+		case IsPatch(v):
+			b.Patch++
+		}
+	}
+
+	if latestStable != nil {
+		latest = latestStable
+	}
+	curPublished := time.Time{}
+	switch {
+	case below != nil:
+		curPublished = published[below]
+	case above != nil:
+		curPublished = published[above]
+	}
+
+	if latest != nil {
+		b.Latest = latest.Original()
+		if !curPublished.IsZero() && !published[latest].IsZero() {
+			b.Lag = max(0, published[latest].Sub(curPublished))
+		}
+	}
+
+	return b, nil
+}
@@ -0,0 +1,185 @@
+package releases
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// BitbucketProvider fetches releases from Bitbucket Cloud. Bitbucket has
+// no releases of its own, so its tags stand in for them, with the
+// annotated tag's message as their notes. The owner is the workspace.
+type BitbucketProvider struct {
+	client   *http.Client
+	username string
+	token    string
+	prefixes tagPrefixes
+}
+
+const (
+	// BitbucketURL is Bitbucket Cloud's web interface.
+	BitbucketURL = "https://bitbucket.org/"
+
+	bitbucketAPI = "https://api.bitbucket.org/2.0/"
+)
+
+// NewBitbucketProvider talks to Bitbucket Cloud, authenticating with an
+// access token, or with an app password when username is set too, unless
+// token is empty.
+func NewBitbucketProvider(client *http.Client, username, token string) *BitbucketProvider {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &BitbucketProvider{client: client, username: username, token: token}
+}
+
+// WithTagPrefix browses only owner/repo's tags that start with prefix,
+// without it: the releases of one component of a monorepo.
+func (p *BitbucketProvider) WithTagPrefix(owner, repo, prefix string) *BitbucketProvider {
+	p.prefixes = p.prefixes.set(owner, repo, prefix)
+	return p
+}
+
+// WebURL is bitbucket.org.
+func (p *BitbucketProvider) WebURL() string {
+	return BitbucketURL
+}
+
+// get fetches link, an absolute API URL, into v.
+func (p *BitbucketProvider) get(ctx context.Context, op, link string, v interface{}) error {
+	start := time.Now()
+	path := strings.TrimPrefix(link, bitbucketAPI)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", link, nil)
+	if err != nil {
+		return err
+	}
+	switch {
+	case p.token != "" && p.username != "":
+		req.SetBasicAuth(p.username, p.token)
+	case p.token != "":
+		req.Header.Set("Authorization", "Bearer "+p.token)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		slog.Debug("bitbucket api call", "op", op, "path", path, "elapsed", time.Since(start), "err", err)
+		return err
+	}
+	defer resp.Body.Close()
+
+	slog.Debug("bitbucket api call", "op", op, "path", path, "elapsed", time.Since(start), "status", resp.StatusCode)
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return statusError(resp.StatusCode, "Bitbucket repository not found (or not visible to you): %s", path)
+	case resp.StatusCode == http.StatusUnauthorized:
+		return statusError(resp.StatusCode, "Bitbucket rejected the credentials: %s", resp.Status)
+	case resp.StatusCode != http.StatusOK:
+		return statusError(resp.StatusCode, "Bitbucket API %s: %s", path, resp.Status)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+type bitbucketTag struct {
+	Name    string    `json:"name"`
+	Message string    `json:"message"`
+	Date    time.Time `json:"date"`
+	Tagger  struct {
+		User struct {
+			Nickname string `json:"nickname"`
+		} `json:"user"`
+	} `json:"tagger"`
+	Target struct {
+		Date   time.Time `json:"date"`
+		Author struct {
+			User struct {
+				Nickname string `json:"nickname"`
+			} `json:"user"`
+		} `json:"author"`
+	} `json:"target"`
+	Links struct {
+		HTML struct {
+			Href string `json:"href"`
+		} `json:"html"`
+	} `json:"links"`
+}
+
+func bitbucketTagsPath(owner, repo string) string {
+	return bitbucketAPI + "repositories/" + url.PathEscape(owner) + "/" + url.PathEscape(repo) + "/refs/tags"
+}
+
+func (p *BitbucketProvider) ListReleases(ctx context.Context, owner, repo string) ([]Release, error) {
+	return p.ListReleasesByPage(ctx, owner, repo, nil)
+}
+
+// ListReleasesByPage follows the pages of tags one after the other,
+// handing each to page as it arrives. Bitbucket links each page to the
+// next rather than saying how many there are.
+func (p *BitbucketProvider) ListReleasesByPage(ctx context.Context, owner, repo string, page func(list []Release, done, total int)) ([]Release, error) {
+	out := []Release{}
+	next := bitbucketTagsPath(owner, repo) + "?pagelen=100"
+
+	for done := 1; next != ""; done++ {
+		var resp struct {
+			Values []bitbucketTag `json:"values"`
+			Next   string         `json:"next"`
+		}
+		if err := p.get(ctx, "ListReleases", next, &resp); err != nil {
+			return nil, err
+		}
+
+		converted := make([]Release, len(resp.Values))
+		for i, t := range resp.Values {
+			converted[i] = fromBitbucket(t)
+		}
+		converted = p.prefixes.strip(owner, repo, converted)
+		out = append(out, converted...)
+
+		if page != nil {
+			page(converted, done, 0)
+		}
+		next = resp.Next
+	}
+
+	return out, nil
+}
+
+func (p *BitbucketProvider) GetRelease(ctx context.Context, owner, repo, tag string) (Release, error) {
+	var t bitbucketTag
+	link := bitbucketTagsPath(owner, repo) + "/" + url.PathEscape(p.prefixes.full(owner, repo, tag))
+	if err := p.get(ctx, "GetRelease", link, &t); err != nil {
+		return Release{}, err
+	}
+
+	release := fromBitbucket(t)
+	release.Tag = tag
+	return release, nil
+}
+
+// fromBitbucket makes a release of a tag. Lightweight tags have no date or
+// tagger of their own, so their commit's stand in.
+func fromBitbucket(t bitbucketTag) Release {
+	release := Release{
+		Tag:         t.Name,
+		Description: strings.TrimSpace(t.Message),
+		URL:         t.Links.HTML.Href,
+		Published:   t.Date,
+		Author:      t.Tagger.User.Nickname,
+	}
+
+	if release.Published.IsZero() {
+		release.Published = t.Target.Date
+	}
+	if release.Author == "" {
+		release.Author = t.Target.Author.User.Nickname
+	}
+
+	return release
+}
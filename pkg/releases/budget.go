@@ -0,0 +1,112 @@
+package releases
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// DefaultReserve is the number of requests kept back for interactive use.
+const DefaultReserve = 100
+
+// Budget tracks the API quota reported by the forge and decides when
+// background work (the pages after the first, and enrichment: issues,
+// milestones and names) may spend it.
+// Interactive requests are never throttled; background requests are paced
+// so that Reserve requests are always left for them, or a quarter of the
+// limit when that's smaller, as it is for anonymous GitHub access's 60. A
+// Budget is safe for concurrent use.
+type Budget struct {
+	Reserve int
+
+	mu        sync.Mutex
+	known     bool
+	remaining int
+	limit     int
+	reset     time.Time
+}
+
+// Budgeted is implemented by providers that track their quota in a
+// Budget, so background work built on top of them can wait its turn.
+type Budgeted interface {
+	Budget() *Budget
+}
+
+func NewBudget(reserve int) *Budget {
+	if reserve <= 0 {
+		reserve = DefaultReserve
+	}
+	return &Budget{Reserve: reserve}
+}
+
+// Update records the quota reported by the latest response.
+func (b *Budget) Update(remaining, limit int, reset time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.known = true
+	b.remaining = remaining
+	b.limit = limit
+	b.reset = reset
+}
+
+// Remaining returns the last reported quota. ok is false until the first
+// response has been seen.
+func (b *Budget) Remaining() (remaining, limit int, reset time.Time, ok bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.remaining, b.limit, b.reset, b.known
+}
+
+// Background blocks until a background request may be made, or ctx is
+// done. Above twice the reserve it returns immediately; closer to the
+// reserve it spreads what's left evenly over the time until reset; at or
+// below the reserve it waits for the reset.
+func (b *Budget) Background(ctx context.Context) error {
+	wait := b.delay(time.Now())
+	if wait <= 0 {
+		return nil
+	}
+
+	slog.Debug("background request throttled", "wait", wait)
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (b *Budget) delay(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	reserve := b.Reserve
+	if b.limit > 0 {
+		reserve = min(reserve, b.limit/4)
+	}
+	if !b.known || b.remaining > 2*reserve {
+		return 0
+	}
+
+	untilReset := b.reset.Sub(now)
+	if untilReset <= 0 {
+		return 0
+	}
+
+	spare := b.remaining - reserve
+	if spare <= 0 {
+		return untilReset
+	}
+
+	// spend one of the spare requests now, and account for it so
+	// concurrent callers are spread out too
+	b.remaining--
+	return untilReset / time.Duration(spare+1)
+}
@@ -0,0 +1,52 @@
+package releases
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBudgetDelay(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		reserve   int
+		known     bool
+		remaining int
+		limit     int
+		reset     time.Duration
+		want      time.Duration
+	}{
+		{"nothing reported yet", 100, false, 0, 0, time.Hour, 0},
+		{"plenty left", 100, true, 4000, 5000, time.Hour, 0},
+		{"reset already passed", 100, true, 150, 5000, -time.Minute, 0},
+		{"spare spread over the window", 100, true, 149, 5000, 50 * time.Minute, time.Minute},
+		{"at the reserve", 100, true, 100, 5000, time.Hour, time.Hour},
+		{"below the reserve", 100, true, 10, 5000, time.Hour, time.Hour},
+		{"reserve capped at a quarter of the limit", 100, true, 31, 60, time.Hour, 0},
+		{"capped reserve reached", 100, true, 15, 60, time.Hour, time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBudget(tt.reserve)
+			if tt.known {
+				b.Update(tt.remaining, tt.limit, now.Add(tt.reset))
+			}
+			if got := b.delay(now); got != tt.want {
+				t.Errorf("delay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBudgetDelaySpreadsConcurrentCallers(t *testing.T) {
+	now := time.Now()
+	b := NewBudget(100)
+	b.Update(103, 5000, now.Add(time.Hour))
+
+	first, second := b.delay(now), b.delay(now)
+	if first != 15*time.Minute || second != 20*time.Minute {
+		t.Errorf("delays = %v, %v, want 15m, 20m", first, second)
+	}
+}
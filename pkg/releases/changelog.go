@@ -0,0 +1,160 @@
+package releases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/google/go-github/v48/github"
+	"github.com/masterminds/semver"
+)
+
+// changelogHeadingRe matches the version headings of a changelog:
+// "## [1.2.3] - 2023-01-02", "## v1.2.3", "# 1.2.3 (2023-01-02)" and the
+// like. Group 1 is the version.
+var changelogHeadingRe = regexp.MustCompile(`^#{1,3}\s+\[?v?(\d+\.\d+(?:\.\d+)?(?:-[0-9A-Za-z.-]+)?)\]?`)
+
+// SplitChangelog breaks a changelog into the section under each version
+// heading, keyed by the version in its canonical form (see semver's
+// Version.String), so tags like "v1.2.3" can find "## [1.2.3]".
+func SplitChangelog(md string) map[string]string {
+	sections := make(map[string]string)
+
+	current := ""
+	var body []string
+	flush := func() {
+		if current != "" {
+			sections[current] = strings.TrimSpace(strings.Join(body, "\n"))
+		}
+	}
+
+	for _, line := range strings.Split(md, "\n") {
+		if m := changelogHeadingRe.FindStringSubmatch(line); m != nil {
+			if v, err := semver.NewVersion(m[1]); err == nil {
+				flush()
+				current, body = v.String(), nil
+				continue
+			}
+		}
+		body = append(body, line)
+	}
+	flush()
+
+	return sections
+}
+
+// changelogFiles are the names looked for in a repository's root, in
+// order.
+var changelogFiles = []string{"CHANGELOG.md", "CHANGES.md", "HISTORY.md", "changelog.md"}
+
+func isNotFound(err error) bool {
+	var ghErr *github.ErrorResponse
+	return errors.As(err, &ghErr) && ghErr.Response != nil && ghErr.Response.StatusCode == http.StatusNotFound
+}
+
+// changelog fetches the repository's changelog, split by version. It's
+// empty if the repository doesn't have one. It's only fetched once per
+// repository: every bare tag's notes come out of it.
+func (p *GitHubProvider) changelog(ctx context.Context, owner, repo string) (map[string]string, error) {
+	key := owner + "/" + repo
+
+	p.changelogsMu.Lock()
+	sections, ok := p.changelogs[key]
+	p.changelogsMu.Unlock()
+	if ok {
+		return sections, nil
+	}
+
+	sections, err := p.fetchChangelog(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	p.changelogsMu.Lock()
+	if p.changelogs == nil {
+		p.changelogs = make(map[string]map[string]string)
+	}
+	p.changelogs[key] = sections
+	p.changelogsMu.Unlock()
+
+	return sections, nil
+}
+
+func (p *GitHubProvider) fetchChangelog(ctx context.Context, owner, repo string) (map[string]string, error) {
+	for _, name := range changelogFiles {
+		var file github.RepositoryContent
+		path := fmt.Sprintf("repos/%s/%s/contents/%s", owner, repo, name)
+		if _, err := p.get(ctx, "GetChangelog", path, &file); isNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		content, err := file.GetContent()
+		if err != nil {
+			return nil, err
+		}
+		return SplitChangelog(content), nil
+	}
+
+	return map[string]string{}, nil
+}
+
+// tagReleases stands in for releases in repositories that tag versions
+// without publishing GitHub releases: each tag becomes a release whose
+// notes are its section of the changelog.
+func (p *GitHubProvider) tagReleases(ctx context.Context, owner, repo string) ([]Release, error) {
+	tags := []*github.RepositoryTag{}
+	for page := 1; page > 0; {
+		// like releases, the pages after the first are background work
+		if page > 1 {
+			if err := p.budget.Background(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		var list []*github.RepositoryTag
+		path := fmt.Sprintf("repos/%s/%s/tags?per_page=100&page=%d", owner, repo, page)
+		resp, err := p.get(ctx, "ListTags", path, &list)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, list...)
+		page = resp.NextPage
+	}
+
+	if len(tags) == 0 {
+		return nil, nil
+	}
+
+	sections, err := p.changelog(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]Release, 0, len(tags))
+	for _, t := range tags {
+		out = append(out, tagRelease(p.WebURL(), owner, repo, t.GetName(), sections))
+	}
+
+	return out, nil
+}
+
+func tagRelease(web, owner, repo, tag string, sections map[string]string) Release {
+	r := Release{
+		Tag: tag,
+		URL: fmt.Sprintf("%s%s/%s/releases/tag/%s", web, owner, repo, tag),
+	}
+
+	if v, err := semver.NewVersion(tag); err == nil {
+		r.Description = sections[v.String()]
+	}
+	if r.Description == "" {
+		r.Description = "_No GitHub release or changelog entry for this tag._"
+	}
+
+	return r
+}
@@ -0,0 +1,48 @@
+package releases
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitChangelog(t *testing.T) {
+	tests := []struct {
+		name string
+		md   string
+		want map[string]string
+	}{
+		{
+			name: "keep a changelog",
+			md:   "# Changelog\n\n## [Unreleased]\n\n- wip\n\n## [1.2.0] - 2023-01-02\n\n### Added\n- a thing\n\n## [1.1.0] - 2022-12-01\n\n- fixed\n",
+			want: map[string]string{"1.2.0": "### Added\n- a thing", "1.1.0": "- fixed"},
+		},
+		{
+			name: "v prefixes and short versions",
+			md:   "## v2.0\nbig\n# 1.9.3 (2022-01-01)\nsmall\n",
+			want: map[string]string{"2.0.0": "big", "1.9.3": "small"},
+		},
+		{
+			name: "prereleases",
+			md:   "### 3.0.0-beta.1\ntry it\n",
+			want: map[string]string{"3.0.0-beta.1": "try it"},
+		},
+		{
+			name: "too deep to be a version heading",
+			md:   "## 1.0.0\n#### 0.9.0\nnested\n",
+			want: map[string]string{"1.0.0": "#### 0.9.0\nnested"},
+		},
+		{
+			name: "no versions",
+			md:   "# Changelog\n\nSee the releases page.\n",
+			want: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SplitChangelog(tt.md); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SplitChangelog() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package releases
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// Commit is one commit between two tags.
+type Commit struct {
+	SHA string
+	// Subject is the first line of the commit message.
+	Subject string
+	// Author is the commit author's name; Login is their account, when
+	// the forge could match one.
+	Author string
+	Login  string
+	Date   time.Time
+	URL    string
+}
+
+// FileChange is one file changed between two tags.
+type FileChange struct {
+	Path      string
+	Status    string // added, modified, removed, renamed...
+	Additions int
+	Deletions int
+}
+
+// Comparison is what changed from one tag to another.
+type Comparison struct {
+	Base, Head string
+	// URL is the comparison's web page.
+	URL string
+	// TotalCommits may exceed len(Commits) for long ranges; the forge only
+	// lists so many.
+	TotalCommits int
+	Commits      []Commit
+	Files        []FileChange
+}
+
+// Additions and Deletions total the changed lines across Files.
+func (c Comparison) Additions() int {
+	n := 0
+	for _, f := range c.Files {
+		n += f.Additions
+	}
+	return n
+}
+
+func (c Comparison) Deletions() int {
+	n := 0
+	for _, f := range c.Files {
+		n += f.Deletions
+	}
+	return n
+}
+
+// Comparer is implemented by providers that can list the commits and file
+// changes between two tags.
+type Comparer interface {
+	Compare(ctx context.Context, owner, repo, base, head string) (Comparison, error)
+}
+
+func (p *GitHubProvider) Compare(ctx context.Context, owner, repo, base, head string) (Comparison, error) {
+	cmp := &github.CommitsComparison{}
+	path := fmt.Sprintf("repos/%s/%s/compare/%s...%s", owner, repo,
+		url.PathEscape(p.prefixes.full(owner, repo, base)), url.PathEscape(p.prefixes.full(owner, repo, head)))
+	if _, err := p.get(ctx, "CompareCommits", path, cmp); err != nil {
+		return Comparison{}, err
+	}
+
+	out := Comparison{
+		Base:         base,
+		Head:         head,
+		URL:          cmp.GetHTMLURL(),
+		TotalCommits: cmp.GetTotalCommits(),
+	}
+
+	for _, c := range cmp.Commits {
+		subject, _, _ := strings.Cut(c.GetCommit().GetMessage(), "\n")
+		out.Commits = append(out.Commits, Commit{
+			SHA:     c.GetSHA(),
+			Subject: subject,
+			Author:  c.GetCommit().GetAuthor().GetName(),
+			Login:   c.GetAuthor().GetLogin(),
+			Date:    c.GetCommit().GetAuthor().GetDate(),
+			URL:     c.GetHTMLURL(),
+		})
+	}
+
+	for _, f := range cmp.Files {
+		out.Files = append(out.Files, FileChange{
+			Path:      f.GetFilename(),
+			Status:    f.GetStatus(),
+			Additions: f.GetAdditions(),
+			Deletions: f.GetDeletions(),
+		})
+	}
+
+	return out, nil
+}
@@ -0,0 +1,96 @@
+package releases
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// testGitHub returns a provider talking to a server that answers with
+// handler.
+func testGitHub(t *testing.T, handler http.Handler) *GitHubProvider {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	gh := github.NewClient(srv.Client())
+	gh.BaseURL, _ = url.Parse(srv.URL + "/")
+	return NewGitHubProvider(gh)
+}
+
+func TestCompare(t *testing.T) {
+	var path string
+	p := testGitHub(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.EscapedPath()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"html_url": "https://github.com/acme/tool/compare/v1.0.0...v1.1.0",
+			"total_commits": 3,
+			"commits": [
+				{"sha": "abc", "html_url": "https://github.com/acme/tool/commit/abc",
+				 "commit": {"message": "Add a flag\n\nLonger description.", "author": {"name": "Ada", "date": "2023-01-02T03:04:05Z"}},
+				 "author": {"login": "ada"}},
+				{"sha": "def", "commit": {"message": "Fix typo", "author": {"name": "Bob"}}}
+			],
+			"files": [
+				{"filename": "main.go", "status": "modified", "additions": 10, "deletions": 2},
+				{"filename": "old.go", "status": "removed", "deletions": 30}
+			]
+		}`))
+	}))
+
+	tests := []struct {
+		name       string
+		prefix     string
+		base, head string
+		wantPath   string
+	}{
+		{"tags", "", "v1.0.0", "v1.1.0", "/repos/acme/tool/compare/v1.0.0...v1.1.0"},
+		{"prefixed tags", "cli/", "v1.0.0", "v1.1.0", "/repos/acme/tool/compare/cli%2Fv1.0.0...cli%2Fv1.1.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p.prefixes = tagPrefixes{}
+			if tt.prefix != "" {
+				p.WithTagPrefix("acme", "tool", tt.prefix)
+			}
+
+			got, err := p.Compare(context.Background(), "acme", "tool", tt.base, tt.head)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if path != tt.wantPath {
+				t.Errorf("requested %s, want %s", path, tt.wantPath)
+			}
+
+			if got.Base != tt.base || got.Head != tt.head || got.TotalCommits != 3 || got.URL == "" {
+				t.Errorf("Compare() = %+v", got)
+			}
+			wantSubjects := []string{"Add a flag", "Fix typo"}
+			if len(got.Commits) != 2 || got.Commits[0].Subject != wantSubjects[0] || got.Commits[1].Subject != wantSubjects[1] {
+				t.Errorf("Commits = %+v, want subjects %v", got.Commits, wantSubjects)
+			}
+			if got.Commits[0].Login != "ada" || got.Commits[0].Author != "Ada" || got.Commits[1].Login != "" {
+				t.Errorf("Commits = %+v", got.Commits)
+			}
+			if !reflect.DeepEqual([]int{got.Additions(), got.Deletions()}, []int{10, 32}) {
+				t.Errorf("Additions(), Deletions() = %d, %d, want 10, 32", got.Additions(), got.Deletions())
+			}
+		})
+	}
+}
+
+func TestCompareNotFound(t *testing.T) {
+	p := testGitHub(t, http.NotFoundHandler())
+
+	if _, err := p.Compare(context.Background(), "acme", "tool", "v1", "v2"); err == nil {
+		t.Error("Compare() succeeded against a 404")
+	}
+}
@@ -0,0 +1,106 @@
+package releases
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// Comment is a top-level comment on a release's discussion thread.
+type Comment struct {
+	Author  string
+	Body    string
+	Created time.Time
+	Upvotes int
+	Replies int
+}
+
+// DiscussionGetter is implemented by providers that can fetch the comments
+// on a discussion thread.
+type DiscussionGetter interface {
+	GetDiscussionComments(ctx context.Context, url string) ([]Comment, error)
+}
+
+var discussionRe = regexp.MustCompile(`^https://[\w.-]+(?::\d+)?/([\w.-]+)/([\w.-]+)/discussions/(\d+)`)
+
+// ParseDiscussionURL splits a GitHub (or GitHub Enterprise) discussion
+// link into its parts.
+func ParseDiscussionURL(url string) (owner, repo string, number int, ok bool) {
+	m := discussionRe.FindStringSubmatch(url)
+	if m == nil {
+		return "", "", 0, false
+	}
+
+	number, _ = strconv.Atoi(m[3])
+	return m[1], m[2], number, true
+}
+
+// maxComments is how many comments are fetched from a discussion, newest
+// last. GraphQL caps a page at 100.
+const maxComments = 100
+
+const discussionQuery = `query($owner: String!, $repo: String!, $number: Int!, $count: Int!) {
+  repository(owner: $owner, name: $repo) {
+    discussion(number: $number) {
+      comments(last: $count) {
+        nodes {
+          author { login }
+          body
+          createdAt
+          upvoteCount
+          replies { totalCount }
+        }
+      }
+    }
+  }
+}`
+
+func (p *GitHubProvider) GetDiscussionComments(ctx context.Context, url string) ([]Comment, error) {
+	owner, repo, number, ok := ParseDiscussionURL(url)
+	if !ok {
+		return nil, fmt.Errorf("not a discussion link: %s", url)
+	}
+
+	var data struct {
+		Repository struct {
+			Discussion *struct {
+				Comments struct {
+					Nodes []struct {
+						Author struct {
+							Login string `json:"login"`
+						} `json:"author"`
+						Body        string    `json:"body"`
+						CreatedAt   time.Time `json:"createdAt"`
+						UpvoteCount int       `json:"upvoteCount"`
+						Replies     struct {
+							TotalCount int `json:"totalCount"`
+						} `json:"replies"`
+					} `json:"nodes"`
+				} `json:"comments"`
+			} `json:"discussion"`
+		} `json:"repository"`
+	}
+
+	vars := map[string]interface{}{"owner": owner, "repo": repo, "number": number, "count": maxComments}
+	if err := p.graphql(ctx, "GetDiscussionComments", discussionQuery, vars, &data); err != nil {
+		return nil, err
+	}
+	if data.Repository.Discussion == nil {
+		return nil, fmt.Errorf("discussion %s not found", url)
+	}
+
+	comments := []Comment{}
+	for _, n := range data.Repository.Discussion.Comments.Nodes {
+		comments = append(comments, Comment{
+			Author:  n.Author.Login,
+			Body:    n.Body,
+			Created: n.CreatedAt,
+			Upvotes: n.UpvoteCount,
+			Replies: n.Replies.TotalCount,
+		})
+	}
+
+	return comments, nil
+}
@@ -0,0 +1,17 @@
+// Package releases fetches, orders and summarizes the releases of a
+// repository, independent of the forge they are hosted on.
+//
+// The stable API is small:
+//
+//	ref, _ := releases.ParseRef("charmbracelet/bubbletea")
+//	all, err := releases.Fetch(ctx, ref, releases.WithProvider(p))
+//	r, _ := releases.Range("0.22.0", "0.23.1")
+//	notes := releases.Aggregate(r.Filter(all))
+//
+// StableOnly drops prereleases and drafts from a list before aggregating.
+//
+// Fetch, Range, StableOnly, Aggregate, Ref, Release and Provider follow
+// the module's semantic version: they will not change incompatibly within
+// a major version. Everything else exported from this package exists to support
+// the brows TUI and may change between minor releases.
+package releases
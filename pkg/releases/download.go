@@ -0,0 +1,408 @@
+package releases
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Downloader saves release assets, verifying them first against any
+// checksums and cosign signatures published alongside them. A file only
+// lands at its final path once every verification passed, and only if
+// nothing is there yet.
+type Downloader struct {
+	// Client fetches assets. Defaults to http.DefaultClient. Assets can
+	// take a while, so it shouldn't have a Timeout; the context passed to
+	// Download bounds them instead.
+	Client *http.Client
+
+	// AllowUnverified saves assets whose checks were skipped, e.g.
+	// because cosign isn't installed. Otherwise a skipped check fails the
+	// download like a failed one does.
+	AllowUnverified bool
+	// Overwrite replaces a file already at the asset's path.
+	Overwrite bool
+
+	// Cosign is the cosign binary. Defaults to "cosign" on the PATH.
+	Cosign string
+	// CosignKey verifies signatures with a public key instead of keyless
+	// certificates.
+	CosignKey string
+	// CertIdentity and CertIssuer are the regexps keyless certificates
+	// must match. They default to the release's repository and GitHub
+	// Actions.
+	CertIdentity string
+	CertIssuer   string
+	// WebURL is the root of the web interface of the forge the releases
+	// come from, with a trailing slash, which the default CertIdentity is
+	// under. Defaults to https://github.com/.
+	WebURL string
+}
+
+// Verification is the outcome of one check on a downloaded asset.
+type Verification struct {
+	// Method is "sha256" or "cosign", or "verification" when the release
+	// publishes nothing to check the asset against.
+	Method string
+	// Detail names what the asset was checked against.
+	Detail string
+	// Skipped explains why a check that applies couldn't run.
+	Skipped string
+	Err     error
+}
+
+func (v Verification) String() string {
+	switch {
+	case v.Err != nil:
+		return fmt.Sprintf("%s ✗ %v", v.Method, v.Err)
+	case v.Skipped != "":
+		return fmt.Sprintf("%s skipped (%s)", v.Method, v.Skipped)
+	default:
+		return fmt.Sprintf("%s ✓", v.Method)
+	}
+}
+
+// VerificationError is returned when a downloaded asset fails a check,
+// or when a check was skipped and skipped checks aren't allowed.
+type VerificationError struct {
+	Asset string
+	Check Verification
+}
+
+func (e *VerificationError) Error() string {
+	if e.Check.Err == nil {
+		return fmt.Sprintf("%s couldn't be verified, not saved: %s", e.Asset, e.Check)
+	}
+	return fmt.Sprintf("%s failed verification, not saved: %s", e.Asset, e.Check)
+}
+
+var checksumsRe = regexp.MustCompile(`(?i)(checksums?|sha256sums?)(\.txt)?$`)
+
+// Download saves asset from release r (of owner/repo) into dir, the
+// current directory if empty, and returns the checks it passed or
+// skipped. If a check fails, or is skipped without AllowUnverified,
+// nothing is saved and the error is a *VerificationError. Without
+// Overwrite, an existing file is left alone and the error is
+// fs.ErrExist.
+func (d Downloader) Download(ctx context.Context, owner, repo string, r Release, asset Asset, dir string) (string, []Verification, error) {
+	return d.DownloadWithProgress(ctx, owner, repo, r, asset, dir, nil)
+}
+
+// DownloadWithProgress is Download, calling progress as the asset's bytes
+// arrive with how many have so far and the asset's size.
+func (d Downloader) DownloadWithProgress(ctx context.Context, owner, repo string, r Release, asset Asset, dir string, progress func(done, total int64)) (string, []Verification, error) {
+	if dir == "" {
+		dir = "."
+	}
+
+	dest := filepath.Join(dir, asset.Name)
+	if err := d.checkDest(dest); err != nil {
+		return "", nil, err
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+asset.Name+".*.part")
+	if err != nil {
+		return "", nil, err
+	}
+	defer os.Remove(tmp.Name())
+
+	sum := sha256.New()
+	w := io.MultiWriter(tmp, sum)
+	if progress != nil {
+		w = io.MultiWriter(w, &progressWriter{total: int64(asset.Size), report: progress})
+	}
+	if err := d.fetch(ctx, asset.URL, w); err != nil {
+		tmp.Close()
+		return "", nil, err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", nil, err
+	}
+
+	checks := []Verification{}
+
+	// a signature on the checksums file vouches for every asset it lists
+	if sums, ok := checksumsFor(r, asset); ok {
+		check, body := d.verifyChecksum(ctx, sums, asset.Name, hex.EncodeToString(sum.Sum(nil)))
+		checks = append(checks, check)
+		if check.Err == nil && check.Skipped == "" {
+			if sig, ok := d.verifyCosignBytes(ctx, owner, repo, r, sums, body); ok {
+				checks = append(checks, sig)
+			}
+		}
+	}
+
+	if sig, ok := d.verifyCosign(ctx, owner, repo, r, asset, tmp.Name()); ok {
+		checks = append(checks, sig)
+	}
+
+	if len(checks) == 0 {
+		checks = append(checks, Verification{Method: "verification", Skipped: "no checksum or signature published"})
+	}
+
+	for _, c := range checks {
+		if c.Err != nil || (c.Skipped != "" && !d.AllowUnverified) {
+			return "", checks, &VerificationError{Asset: asset.Name, Check: c}
+		}
+	}
+
+	// again, in case something landed there while the asset downloaded
+	if err := d.checkDest(dest); err != nil {
+		return "", checks, err
+	}
+	if err := os.Rename(tmp.Name(), dest); err != nil {
+		return "", checks, err
+	}
+
+	return dest, checks, nil
+}
+
+// checkDest refuses to replace the file at dest, unless Overwrite is set.
+func (d Downloader) checkDest(dest string) error {
+	if d.Overwrite {
+		return nil
+	}
+
+	_, err := os.Lstat(dest)
+	switch {
+	case err == nil:
+		return &fs.PathError{Op: "save", Path: dest, Err: fs.ErrExist}
+	case errors.Is(err, fs.ErrNotExist):
+		return nil
+	default:
+		return err
+	}
+}
+
+func (d Downloader) client() *http.Client {
+	if d.Client != nil {
+		return d.Client
+	}
+	return http.DefaultClient
+}
+
+func (d Downloader) fetch(ctx context.Context, url string, w io.Writer) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/octet-stream")
+
+	resp, err := d.client().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("downloading %s: %s", url, resp.Status)
+	}
+
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+// progressWriter counts the bytes written through it.
+type progressWriter struct {
+	done, total int64
+	report      func(done, total int64)
+}
+
+func (w *progressWriter) Write(p []byte) (int, error) {
+	w.done += int64(len(p))
+	w.report(w.done, w.total)
+	return len(p), nil
+}
+
+func (d Downloader) fetchBytes(ctx context.Context, url string) ([]byte, error) {
+	var b bytes.Buffer
+	err := d.fetch(ctx, url, &b)
+	return b.Bytes(), err
+}
+
+// findAsset returns the asset of r named name.
+func findAsset(r Release, name string) (Asset, bool) {
+	for _, a := range r.Assets {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return Asset{}, false
+}
+
+// checksumsFor finds the file listing asset's checksum: a per-asset
+// <name>.sha256, or a release-wide checksums.txt / SHA256SUMS.
+func checksumsFor(r Release, asset Asset) (Asset, bool) {
+	if a, ok := findAsset(r, asset.Name+".sha256"); ok {
+		return a, true
+	}
+
+	for _, a := range r.Assets {
+		if a.Name != asset.Name && checksumsRe.MatchString(a.Name) {
+			return a, true
+		}
+	}
+
+	return Asset{}, false
+}
+
+func (d Downloader) verifyChecksum(ctx context.Context, sums Asset, name, got string) (Verification, []byte) {
+	v := Verification{Method: "sha256", Detail: sums.Name}
+
+	body, err := d.fetchBytes(ctx, sums.URL)
+	if err != nil {
+		v.Skipped = err.Error()
+		return v, nil
+	}
+
+	want := ""
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		switch {
+		case len(fields) == 1 && strings.HasSuffix(sums.Name, ".sha256"):
+			want = fields[0]
+		case len(fields) >= 2 && strings.TrimPrefix(fields[1], "*") == name:
+			want = fields[0]
+		}
+	}
+
+	switch {
+	case want == "":
+		v.Skipped = "not listed in " + sums.Name
+	case !strings.EqualFold(want, got):
+		v.Err = fmt.Errorf("checksum mismatch against %s", sums.Name)
+	}
+
+	return v, body
+}
+
+// verifyCosignBytes is verifyCosign for an asset held in memory.
+func (d Downloader) verifyCosignBytes(ctx context.Context, owner, repo string, r Release, target Asset, data []byte) (Verification, bool) {
+	f, err := os.CreateTemp("", "brows-blob-")
+	if err != nil {
+		return Verification{Method: "cosign", Detail: target.Name, Skipped: err.Error()}, true
+	}
+	defer os.Remove(f.Name())
+
+	_, err = f.Write(data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return Verification{Method: "cosign", Detail: target.Name, Skipped: err.Error()}, true
+	}
+
+	return d.verifyCosign(ctx, owner, repo, r, target, f.Name())
+}
+
+// verifyCosign checks the signature of target, saved at blob, if the
+// release carries one, by running cosign verify-blob. ok is false when
+// target isn't signed.
+func (d Downloader) verifyCosign(ctx context.Context, owner, repo string, r Release, target Asset, blob string) (Verification, bool) {
+	v := Verification{Method: "cosign", Detail: target.Name}
+
+	args := []string{"verify-blob"}
+	files := map[string]Asset{}
+
+	bundle, hasBundle := findAsset(r, target.Name+".sigstore.json")
+	if !hasBundle {
+		bundle, hasBundle = findAsset(r, target.Name+".bundle")
+	}
+	sig, hasSig := findAsset(r, target.Name+".sig")
+	cert, hasCert := findAsset(r, target.Name+".pem")
+	if !hasCert {
+		cert, hasCert = findAsset(r, target.Name+".crt")
+	}
+
+	switch {
+	case hasBundle:
+		files["--bundle"] = bundle
+	case hasSig:
+		files["--signature"] = sig
+		if hasCert && d.CosignKey == "" {
+			files["--certificate"] = cert
+		}
+	default:
+		return v, false
+	}
+
+	cosign := d.Cosign
+	if cosign == "" {
+		cosign = "cosign"
+	}
+	if _, err := exec.LookPath(cosign); err != nil {
+		v.Skipped = "cosign not installed"
+		return v, true
+	}
+
+	dir, err := os.MkdirTemp("", "brows-verify-")
+	if err != nil {
+		v.Skipped = err.Error()
+		return v, true
+	}
+	defer os.RemoveAll(dir)
+
+	for flag, a := range files {
+		body, err := d.fetchBytes(ctx, a.URL)
+		if err != nil {
+			v.Skipped = err.Error()
+			return v, true
+		}
+
+		path := filepath.Join(dir, a.Name)
+		if err := os.WriteFile(path, body, 0600); err != nil {
+			v.Skipped = err.Error()
+			return v, true
+		}
+		args = append(args, flag, path)
+	}
+
+	if d.CosignKey != "" {
+		args = append(args, "--key", d.CosignKey)
+	} else {
+		identity := d.CertIdentity
+		if identity == "" {
+			web := d.WebURL
+			if web == "" {
+				web = "https://github.com/"
+			}
+			identity = "^" + regexp.QuoteMeta(web+owner+"/"+repo+"/")
+		}
+		issuer := d.CertIssuer
+		if issuer == "" {
+			issuer = `^https://token\.actions\.githubusercontent\.com$`
+		}
+		args = append(args, "--certificate-identity-regexp", identity, "--certificate-oidc-issuer-regexp", issuer)
+	}
+
+	args = append(args, blob)
+
+	out, err := exec.CommandContext(ctx, cosign, args...).CombinedOutput()
+	if err != nil {
+		v.Err = fmt.Errorf("%s", firstLine(string(out), err.Error()))
+	}
+
+	return v, true
+}
+
+func firstLine(s, fallback string) string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return fallback
+	}
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
@@ -0,0 +1,118 @@
+package releases
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestVerifyChecksum(t *testing.T) {
+	const sum = "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+
+	files := map[string]string{
+		"/checksums.txt":      "0000  other.tar.gz\n" + sum + "  tool.tar.gz\n",
+		"/SHA256SUMS":         sum + " *tool.tar.gz\n",
+		"/tool.tar.gz.sha256": sum + "\n",
+		"/upper.txt":          "9F86D081884C7D659A2FEAA0C55AD015A3BF4F1B2B0B822CD15D6C15B0F00A08  tool.tar.gz\n",
+		"/unlisted.txt":       sum + "  other.tar.gz\n",
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name        string
+		sums        string
+		got         string
+		wantSkipped bool
+		wantErr     bool
+	}{
+		{"release-wide list", "checksums.txt", sum, false, false},
+		{"binary mode marker", "SHA256SUMS", sum, false, false},
+		{"per-asset file", "tool.tar.gz.sha256", sum, false, false},
+		{"case-insensitive", "upper.txt", sum, false, false},
+		{"mismatch", "checksums.txt", "deadbeef", false, true},
+		{"not listed", "unlisted.txt", sum, true, false},
+		{"unavailable", "missing.txt", sum, true, false},
+	}
+
+	d := Downloader{Client: srv.Client()}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, _ := d.verifyChecksum(context.Background(), Asset{Name: tt.sums, URL: srv.URL + "/" + tt.sums}, "tool.tar.gz", tt.got)
+			if (v.Skipped != "") != tt.wantSkipped {
+				t.Errorf("Skipped = %q, want skipped %v", v.Skipped, tt.wantSkipped)
+			}
+			if (v.Err != nil) != tt.wantErr {
+				t.Errorf("Err = %v, wantErr %v", v.Err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestChecksumsFor(t *testing.T) {
+	tests := []struct {
+		name   string
+		assets []string
+		want   string
+	}{
+		{"per-asset file first", []string{"tool.tar.gz", "checksums.txt", "tool.tar.gz.sha256"}, "tool.tar.gz.sha256"},
+		{"release-wide list", []string{"tool.tar.gz", "tool_1.0.0_checksums.txt"}, "tool_1.0.0_checksums.txt"},
+		{"none", []string{"tool.tar.gz", "tool.tar.gz.sig"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Release{}
+			for _, name := range tt.assets {
+				r.Assets = append(r.Assets, Asset{Name: name})
+			}
+			got, _ := checksumsFor(r, Asset{Name: "tool.tar.gz"})
+			if got.Name != tt.want {
+				t.Errorf("checksumsFor() = %q, want %q", got.Name, tt.want)
+			}
+		})
+	}
+}
+
+func TestDownloadWithNothingToCheck(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("binary"))
+	}))
+	defer srv.Close()
+
+	asset := Asset{Name: "tool.tar.gz", URL: srv.URL + "/tool.tar.gz"}
+	r := Release{Tag: "v1.0.0", Assets: []Asset{asset}}
+
+	tests := []struct {
+		name            string
+		allowUnverified bool
+		wantSaved       bool
+	}{
+		{"refused", false, false},
+		{"allowed", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := Downloader{Client: srv.Client(), AllowUnverified: tt.allowUnverified}
+			path, checks, err := d.Download(context.Background(), "acme", "tool", r, asset, t.TempDir())
+
+			if len(checks) != 1 || checks[0].Skipped == "" {
+				t.Errorf("checks = %v, want one skipped", checks)
+			}
+			var verr *VerificationError
+			if saved := err == nil && path != ""; saved != tt.wantSaved || !tt.wantSaved && !errors.As(err, &verr) {
+				t.Errorf("Download() = %q, %v", path, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package releases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// StatusError is an API call a forge answered with an error status.
+type StatusError struct {
+	Code int
+	Err  error
+}
+
+func (e *StatusError) Error() string { return e.Err.Error() }
+func (e *StatusError) Unwrap() error { return e.Err }
+
+func statusError(code int, format string, args ...any) error {
+	return &StatusError{Code: code, Err: fmt.Errorf(format, args...)}
+}
+
+// Failure is the kind of error a forge request ended in, as far as the
+// user can do something about it.
+type Failure int
+
+const (
+	FailureOther Failure = iota
+	FailureAuth
+	FailureNotFound
+	FailureRateLimit
+	FailureNetwork
+)
+
+func (f Failure) String() string {
+	switch f {
+	case FailureAuth:
+		return "not authorized"
+	case FailureNotFound:
+		return "not found"
+	case FailureRateLimit:
+		return "rate limited"
+	case FailureNetwork:
+		return "network error"
+	}
+	return "request failed"
+}
+
+// Classify tells what kind of failure err is.
+func Classify(err error) Failure {
+	var (
+		authErr   *AuthError
+		rateErr   *github.RateLimitError
+		abuseErr  *github.AbuseRateLimitError
+		ghErr     *github.ErrorResponse
+		statusErr *StatusError
+		netErr    net.Error
+	)
+
+	code := 0
+	switch {
+	case err == nil:
+		return FailureOther
+	case errors.As(err, &authErr):
+		if authErr.NotFound {
+			return FailureNotFound
+		}
+		return FailureAuth
+	case errors.As(err, &rateErr), errors.As(err, &abuseErr):
+		return FailureRateLimit
+	case errors.As(err, &ghErr) && ghErr.Response != nil:
+		code = ghErr.Response.StatusCode
+	case errors.As(err, &statusErr):
+		code = statusErr.Code
+	case errors.As(err, &netErr), errors.Is(err, context.DeadlineExceeded):
+		return FailureNetwork
+	}
+
+	switch code {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return FailureAuth
+	case http.StatusNotFound:
+		return FailureNotFound
+	case http.StatusTooManyRequests:
+		return FailureRateLimit
+	}
+	return FailureOther
+}
@@ -0,0 +1,70 @@
+// Package fake provides an in-memory releases.Provider for tests and for
+// embedding brows without network access.
+package fake
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/rubysolo/brows/pkg/releases"
+)
+
+// Provider serves releases registered with Add. It is safe for concurrent
+// use.
+type Provider struct {
+	mu       sync.Mutex
+	releases map[string][]releases.Release
+
+	// Err, when set, is returned from every call instead of releases.
+	Err error
+}
+
+func New() *Provider {
+	return &Provider{releases: make(map[string][]releases.Release)}
+}
+
+// Add registers releases for owner/repo, appending to any already added.
+func (p *Provider) Add(owner, repo string, rs ...releases.Release) *Provider {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := fmt.Sprintf("%s/%s", owner, repo)
+	p.releases[key] = append(p.releases[key], rs...)
+
+	return p
+}
+
+func (p *Provider) ListReleases(ctx context.Context, owner, repo string) ([]releases.Release, error) {
+	if p.Err != nil {
+		return nil, p.Err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	rs, ok := p.releases[fmt.Sprintf("%s/%s", owner, repo)]
+	if !ok {
+		return nil, fmt.Errorf("fake: no releases for %s/%s", owner, repo)
+	}
+
+	out := make([]releases.Release, len(rs))
+	copy(out, rs)
+
+	return out, nil
+}
+
+func (p *Provider) GetRelease(ctx context.Context, owner, repo, tag string) (releases.Release, error) {
+	rs, err := p.ListReleases(ctx, owner, repo)
+	if err != nil {
+		return releases.Release{}, err
+	}
+
+	for _, r := range rs {
+		if r.Tag == tag {
+			return r, nil
+		}
+	}
+
+	return releases.Release{}, fmt.Errorf("fake: no release %s in %s/%s", tag, owner, repo)
+}
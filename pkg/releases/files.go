@@ -0,0 +1,75 @@
+package releases
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// GetFile fetches path from owner/repo as of tag through the contents API.
+func (p *GitHubProvider) GetFile(ctx context.Context, owner, repo, tag, path string) (string, error) {
+	var file github.RepositoryContent
+	apiPath := fmt.Sprintf("repos/%s/%s/contents/%s?ref=%s", owner, repo, escapePath(path), url.QueryEscape(p.prefixes.full(owner, repo, tag)))
+	if _, err := p.get(ctx, "GetFile", apiPath, &file); err != nil {
+		return "", err
+	}
+	if file.GetType() != "file" {
+		return "", fmt.Errorf("%s is not a file", path)
+	}
+
+	return file.GetContent()
+}
+
+// GetFile fetches path from owner/repo as of tag through the repository
+// files API.
+func (p *GitLabProvider) GetFile(ctx context.Context, owner, repo, tag, path string) (string, error) {
+	var file struct {
+		Content string `json:"content"`
+	}
+	apiPath := fmt.Sprintf("%s/repository/files/%s?ref=%s", projectPath(owner, repo), url.PathEscape(path), url.QueryEscape(p.prefixes.full(owner, repo, tag)))
+	if _, err := p.get(ctx, "GetFile", apiPath, &file); err != nil {
+		return "", err
+	}
+
+	return decodeBase64(file.Content)
+}
+
+// GetFile fetches path from owner/repo as of tag through the contents API,
+// which Gitea models on GitHub's.
+func (p *GiteaProvider) GetFile(ctx context.Context, owner, repo, tag, path string) (string, error) {
+	var file struct {
+		Type    string `json:"type"`
+		Content string `json:"content"`
+	}
+	apiPath := fmt.Sprintf("%s/contents/%s?ref=%s", giteaRepoPath(owner, repo), escapePath(path), url.QueryEscape(p.prefixes.full(owner, repo, tag)))
+	if err := p.get(ctx, "GetFile", apiPath, &file); err != nil {
+		return "", err
+	}
+	if file.Type != "file" {
+		return "", fmt.Errorf("%s is not a file", path)
+	}
+
+	return decodeBase64(file.Content)
+}
+
+// escapePath escapes each segment of a slash-separated path.
+func escapePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
+
+func decodeBase64(s string) (string, error) {
+	// some forges wrap the encoded content across lines
+	out, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(s, "\n", ""))
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
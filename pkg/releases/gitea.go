@@ -0,0 +1,175 @@
+package releases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// GiteaProvider fetches releases through the Gitea REST API, which Forgejo
+// (and so Codeberg) shares, from codeberg.org or a self-hosted instance.
+type GiteaProvider struct {
+	client   *http.Client
+	baseURL  string
+	token    string
+	prefixes tagPrefixes
+}
+
+// DefaultGiteaURL is Codeberg, the largest public Forgejo instance.
+const DefaultGiteaURL = "https://codeberg.org/"
+
+// giteaPageSize is the most releases Gitea hands out per page by default.
+const giteaPageSize = 50
+
+// NewGiteaProvider talks to the Gitea or Forgejo at baseURL
+// (DefaultGiteaURL if empty), authenticating with token unless it's empty.
+func NewGiteaProvider(client *http.Client, baseURL, token string) *GiteaProvider {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if baseURL == "" {
+		baseURL = DefaultGiteaURL
+	}
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+
+	return &GiteaProvider{client: client, baseURL: baseURL, token: token}
+}
+
+// WithTagPrefix browses only owner/repo's tags that start with prefix,
+// without it: the releases of one component of a monorepo.
+func (p *GiteaProvider) WithTagPrefix(owner, repo, prefix string) *GiteaProvider {
+	p.prefixes = p.prefixes.set(owner, repo, prefix)
+	return p
+}
+
+// WebURL is the root of the Gitea instance, with a trailing slash.
+func (p *GiteaProvider) WebURL() string {
+	return p.baseURL
+}
+
+// get fetches path below /api/v1/ into v.
+func (p *GiteaProvider) get(ctx context.Context, op, path string, v interface{}) error {
+	start := time.Now()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", p.baseURL+"api/v1/"+path, nil)
+	if err != nil {
+		return err
+	}
+	if p.token != "" {
+		req.Header.Set("Authorization", "token "+p.token)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		slog.Debug("gitea api call", "op", op, "path", path, "elapsed", time.Since(start), "err", err)
+		return err
+	}
+	defer resp.Body.Close()
+
+	slog.Debug("gitea api call", "op", op, "path", path, "elapsed", time.Since(start), "status", resp.StatusCode)
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return statusError(resp.StatusCode, "Gitea repository not found (or not visible to you): %s", path)
+	case resp.StatusCode == http.StatusUnauthorized:
+		return statusError(resp.StatusCode, "Gitea rejected the token: %s", resp.Status)
+	case resp.StatusCode != http.StatusOK:
+		return statusError(resp.StatusCode, "Gitea API %s: %s", path, resp.Status)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+type giteaRelease struct {
+	TagName     string    `json:"tag_name"`
+	Name        string    `json:"name"`
+	Body        string    `json:"body"`
+	HTMLURL     string    `json:"html_url"`
+	PublishedAt time.Time `json:"published_at"`
+	Prerelease  bool      `json:"prerelease"`
+	Draft       bool      `json:"draft"`
+	Author      struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	Assets []struct {
+		Name               string `json:"name"`
+		Size               int    `json:"size"`
+		BrowserDownloadURL string `json:"browser_download_url"`
+	} `json:"assets"`
+}
+
+func giteaRepoPath(owner, repo string) string {
+	return "repos/" + url.PathEscape(owner) + "/" + url.PathEscape(repo)
+}
+
+func (p *GiteaProvider) ListReleases(ctx context.Context, owner, repo string) ([]Release, error) {
+	return p.ListReleasesByPage(ctx, owner, repo, nil)
+}
+
+// ListReleasesByPage follows the pages of releases one after the other,
+// handing each to page as it arrives, until a short page says there are
+// no more.
+func (p *GiteaProvider) ListReleasesByPage(ctx context.Context, owner, repo string, page func(list []Release, done, total int)) ([]Release, error) {
+	out := []Release{}
+
+	for n := 1; ; n++ {
+		var list []giteaRelease
+		path := fmt.Sprintf("%s/releases?limit=%d&page=%d", giteaRepoPath(owner, repo), giteaPageSize, n)
+
+		if err := p.get(ctx, "ListReleases", path, &list); err != nil {
+			return nil, err
+		}
+
+		converted := make([]Release, len(list))
+		for i, r := range list {
+			converted[i] = fromGitea(r)
+		}
+		converted = p.prefixes.strip(owner, repo, converted)
+		out = append(out, converted...)
+
+		if page != nil {
+			page(converted, n, 0)
+		}
+		if len(list) < giteaPageSize {
+			return out, nil
+		}
+	}
+}
+
+func (p *GiteaProvider) GetRelease(ctx context.Context, owner, repo, tag string) (Release, error) {
+	var r giteaRelease
+	path := fmt.Sprintf("%s/releases/tags/%s", giteaRepoPath(owner, repo), url.PathEscape(p.prefixes.full(owner, repo, tag)))
+	if err := p.get(ctx, "GetRelease", path, &r); err != nil {
+		return Release{}, err
+	}
+
+	release := fromGitea(r)
+	release.Tag = tag
+	return release, nil
+}
+
+func fromGitea(r giteaRelease) Release {
+	release := Release{
+		Tag:         r.TagName,
+		Name:        r.Name,
+		Description: r.Body,
+		URL:         r.HTMLURL,
+		Published:   r.PublishedAt,
+		Prerelease:  r.Prerelease,
+		Draft:       r.Draft,
+		Author:      r.Author.Login,
+	}
+
+	for _, a := range r.Assets {
+		release.Assets = append(release.Assets, Asset{Name: a.Name, URL: a.BrowserDownloadURL, Size: a.Size})
+	}
+
+	return release
+}
@@ -0,0 +1,330 @@
+package releases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// GitHubProvider fetches releases through the GitHub REST API.
+type GitHubProvider struct {
+	gh     *github.Client
+	budget *Budget
+
+	prefixes tagPrefixes
+
+	useGraphQL bool
+	// graphqlOff is set once the token turned out not to work with GraphQL.
+	graphqlOff atomic.Bool
+
+	// changelogs holds each repository's changelog, split by version,
+	// once it's been fetched for its tags.
+	changelogsMu sync.Mutex
+	changelogs   map[string]map[string]string
+}
+
+func NewGitHubProvider(gh *github.Client) *GitHubProvider {
+	return &GitHubProvider{gh: gh, budget: NewBudget(DefaultReserve)}
+}
+
+// WithTagPrefix browses only owner/repo's tags that start with prefix,
+// without it: the releases of one component of a monorepo.
+func (p *GitHubProvider) WithTagPrefix(owner, repo, prefix string) *GitHubProvider {
+	p.prefixes = p.prefixes.set(owner, repo, prefix)
+	return p
+}
+
+// WithBudget shares b between this provider and any background work
+// built on top of it.
+func (p *GitHubProvider) WithBudget(b *Budget) *GitHubProvider {
+	p.budget = b
+	return p
+}
+
+// Budget returns the quota tracker fed by this provider's responses.
+func (p *GitHubProvider) Budget() *Budget {
+	return p.budget
+}
+
+// WebURL is the root of the web interface the provider's API belongs to,
+// with a trailing slash: https://github.com/, or a GitHub Enterprise
+// Server's own host.
+func (p *GitHubProvider) WebURL() string {
+	base := p.gh.BaseURL
+	if base.Host == "api.github.com" {
+		return "https://github.com/"
+	}
+	return base.Scheme + "://" + base.Host + "/"
+}
+
+func (p *GitHubProvider) track(resp *github.Response) {
+	if resp != nil && resp.Rate.Limit > 0 {
+		p.budget.Update(resp.Rate.Remaining, resp.Rate.Limit, resp.Rate.Reset.Time)
+	}
+}
+
+// get fetches path from the REST API into v, tracking quota and timing.
+func (p *GitHubProvider) get(ctx context.Context, op, path string, v interface{}) (*github.Response, error) {
+	start := time.Now()
+
+	req, err := p.gh.NewRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.gh.Do(ctx, req, v)
+	p.track(resp)
+	slog.Debug("github api call", "op", op, "path", path, "elapsed", time.Since(start), "err", err)
+	if err != nil {
+		return resp, wrapGitHubError(err)
+	}
+
+	return resp, nil
+}
+
+// graphql runs a GraphQL query, decoding its data into v. Some things,
+// like discussions, only exist in the GraphQL API.
+func (p *GitHubProvider) graphql(ctx context.Context, op, query string, vars map[string]interface{}, v interface{}) error {
+	start := time.Now()
+
+	// GitHub Enterprise Server serves GraphQL at /api/graphql, next to
+	// the REST API's /api/v3/ rather than inside it
+	endpoint := "graphql"
+	if strings.HasSuffix(p.gh.BaseURL.Path, "/api/v3/") {
+		endpoint = "../graphql"
+	}
+
+	req, err := p.gh.NewRequest("POST", endpoint, map[string]interface{}{"query": query, "variables": vars})
+	if err != nil {
+		return err
+	}
+
+	out := struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}{}
+
+	resp, err := p.gh.Do(ctx, req, &out)
+	p.track(resp)
+	slog.Debug("github graphql call", "op", op, "elapsed", time.Since(start), "err", err)
+	if err != nil {
+		return wrapGitHubError(err)
+	}
+
+	if len(out.Errors) > 0 {
+		return fmt.Errorf("%s: %s", op, out.Errors[0].Message)
+	}
+
+	return json.Unmarshal(out.Data, v)
+}
+
+// githubRelease adds the fields go-github doesn't know about yet.
+type githubRelease struct {
+	github.RepositoryRelease
+	DiscussionURL *string `json:"discussion_url,omitempty"`
+	Reactions     *struct {
+		TotalCount int `json:"total_count"`
+	} `json:"reactions,omitempty"`
+}
+
+func (p *GitHubProvider) ListReleases(ctx context.Context, owner, repo string) ([]Release, error) {
+	return p.ListReleasesWithProgress(ctx, owner, repo, nil)
+}
+
+// pageConcurrency bounds how many pages of releases are fetched at once.
+const pageConcurrency = 4
+
+// ListReleasesWithProgress fetches every page of releases, reporting how
+// many have arrived.
+func (p *GitHubProvider) ListReleasesWithProgress(ctx context.Context, owner, repo string, progress func(done, total int)) ([]Release, error) {
+	return p.ListReleasesByPage(ctx, owner, repo, func(_ []Release, done, total int) {
+		if progress != nil {
+			progress(done, total)
+		}
+	})
+}
+
+// ListReleasesByPage fetches every page of releases, through GraphQL if
+// it's enabled, or else from REST: the first page to learn how many there
+// are, then the rest concurrently. Pages are handed to page as they
+// arrive, which for REST isn't necessarily in order.
+func (p *GitHubProvider) ListReleasesByPage(ctx context.Context, owner, repo string, page func(list []Release, done, total int)) ([]Release, error) {
+	if page == nil {
+		page = func([]Release, int, int) {}
+	}
+
+	list, err := p.listReleasesByPage(ctx, owner, repo, func(list []Release, done, total int) {
+		page(p.prefixes.strip(owner, repo, list), done, total)
+	})
+	return p.prefixes.strip(owner, repo, list), err
+}
+
+func (p *GitHubProvider) listReleasesByPage(ctx context.Context, owner, repo string, page func(list []Release, done, total int)) ([]Release, error) {
+
+	if list, ok, err := p.tryGraphQL(ctx, owner, repo, page); err != nil {
+		return nil, err
+	} else if ok {
+		if len(list) == 0 {
+			return p.tagReleases(ctx, owner, repo)
+		}
+		return list, nil
+	}
+
+	pagePath := func(page int) string {
+		return fmt.Sprintf("repos/%s/%s/releases?per_page=100&page=%d", owner, repo, page)
+	}
+
+	var first []*githubRelease
+	resp, err := p.get(ctx, "ListReleases", pagePath(1), &first)
+	if err != nil {
+		return nil, err
+	}
+
+	last := max(1, resp.LastPage)
+	pages := make([][]*githubRelease, last)
+	pages[0] = first
+
+	var (
+		mu   sync.Mutex
+		done = 1
+	)
+	page(fromGitHubList(first), done, last)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		firstErr error
+	)
+	sem := make(chan struct{}, pageConcurrency)
+	for n := 2; n <= last; n++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			// the pages after the first are background work
+			var list []*githubRelease
+			err := p.budget.Background(ctx)
+			if err == nil {
+				_, err = p.get(ctx, "ListReleases", pagePath(n), &list)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				return
+			}
+			pages[n-1] = list
+			done++
+			page(fromGitHubList(list), done, last)
+		}(n)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	out := []Release{}
+	for _, list := range pages {
+		out = append(out, fromGitHubList(list)...)
+	}
+
+	if len(out) == 0 {
+		return p.tagReleases(ctx, owner, repo)
+	}
+
+	return out, nil
+}
+
+func (p *GitHubProvider) GetRelease(ctx context.Context, owner, repo, tag string) (Release, error) {
+	release, err := p.getRelease(ctx, owner, repo, p.prefixes.full(owner, repo, tag))
+	release.Tag = tag
+	return release, err
+}
+
+func (p *GitHubProvider) getRelease(ctx context.Context, owner, repo, tag string) (Release, error) {
+	r := &githubRelease{}
+	path := fmt.Sprintf("repos/%s/%s/releases/tags/%s", owner, repo, url.PathEscape(tag))
+	if _, err := p.get(ctx, "GetReleaseByTag", path, r); isNotFound(err) {
+		// maybe a bare tag, listed by tagReleases
+		var ref github.Reference
+		refPath := fmt.Sprintf("repos/%s/%s/git/ref/tags/%s", owner, repo, url.PathEscape(tag))
+		if _, refErr := p.get(ctx, "GetTagRef", refPath, &ref); isNotFound(refErr) {
+			return Release{}, err
+		} else if refErr != nil {
+			return Release{}, refErr
+		}
+
+		sections, err := p.changelog(ctx, owner, repo)
+		if err != nil {
+			return Release{}, err
+		}
+		return tagRelease(p.WebURL(), owner, repo, tag, sections), nil
+	} else if err != nil {
+		return Release{}, err
+	}
+
+	return fromGitHub(r), nil
+}
+
+func fromGitHubList(list []*githubRelease) []Release {
+	out := make([]Release, len(list))
+	for i, r := range list {
+		out[i] = fromGitHub(r)
+	}
+	return out
+}
+
+func fromGitHub(r *githubRelease) Release {
+	release := Release{
+		Tag:           asString(r.TagName),
+		Name:          asString(r.Name),
+		Description:   asString(r.Body),
+		URL:           r.GetHTMLURL(),
+		DiscussionURL: asString(r.DiscussionURL),
+		Published:     r.GetPublishedAt().Time,
+		Author:        r.GetAuthor().GetLogin(),
+		Prerelease:    r.GetPrerelease(),
+		Draft:         r.GetDraft(),
+	}
+
+	if r.Reactions != nil {
+		release.Reactions = r.Reactions.TotalCount
+	}
+
+	for _, a := range r.Assets {
+		release.Assets = append(release.Assets, Asset{
+			Name: a.GetName(),
+			URL:  a.GetBrowserDownloadURL(),
+			Size: a.GetSize(),
+		})
+	}
+
+	return release
+}
+
+func asString(s *string) string {
+	if s == nil {
+		temp := ""
+		s = &temp
+	}
+	return *s
+}
@@ -0,0 +1,162 @@
+package releases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// AuthError is returned when GitHub refuses a request because the token
+// lacks a required scope or hasn't been authorized for an organization's
+// SAML single sign-on.
+type AuthError struct {
+	Err error
+
+	// SSOURL is where the token can be authorized for the organization.
+	SSOURL string
+	// AcceptedScopes are the scopes the endpoint accepts.
+	AcceptedScopes []string
+	// TokenScopes are the scopes the token was granted.
+	TokenScopes []string
+	// NotFound is set when GitHub answered 404, as it does for private
+	// repositories a token can't see.
+	NotFound bool
+}
+
+func (e *AuthError) Error() string {
+	var b strings.Builder
+	b.WriteString(e.Err.Error())
+
+	if e.NotFound {
+		b.WriteString("\n\nGitHub answers \"not found\" for private repositories a token can't see, and yours")
+		fmt.Fprintf(&b, " has no access to private ones. It has: %s", orNone(e.TokenScopes))
+		b.WriteString("\nIf this repository is private, add the repo scope to it")
+		b.WriteString("\n(for gh users: gh auth refresh -s repo)\n")
+		return b.String()
+	}
+
+	if e.SSOURL != "" {
+		b.WriteString("\n\nThis organization uses SAML single sign-on and your token hasn't been authorized for it.")
+		fmt.Fprintf(&b, "\nAuthorize it here, then try again:\n\n  %s\n", e.SSOURL)
+	}
+
+	if missing := e.MissingScopes(); len(missing) > 0 {
+		fmt.Fprintf(&b, "\n\nYour token is missing a required scope. It has: %s", orNone(e.TokenScopes))
+		fmt.Fprintf(&b, "\nAdd one of these scopes to it: %s", strings.Join(missing, ", "))
+		b.WriteString("\n(for gh users: gh auth refresh -s " + missing[0] + ")\n")
+	}
+
+	return b.String()
+}
+
+func (e *AuthError) Unwrap() error {
+	return e.Err
+}
+
+// MissingScopes returns the accepted scopes, if the token has none of them.
+func (e *AuthError) MissingScopes() []string {
+	for _, accepted := range e.AcceptedScopes {
+		for _, have := range e.TokenScopes {
+			if accepted == have {
+				return nil
+			}
+		}
+	}
+	return e.AcceptedScopes
+}
+
+func orNone(scopes []string) string {
+	if len(scopes) == 0 {
+		return "(none)"
+	}
+	return strings.Join(scopes, ", ")
+}
+
+// wrapGitHubError inspects the response headers of a failed API call and
+// returns an *AuthError when they explain the failure.
+func wrapGitHubError(err error) error {
+	var ghErr *github.ErrorResponse
+	if !errors.As(err, &ghErr) || ghErr.Response == nil {
+		return err
+	}
+
+	resp := ghErr.Response
+	if resp.StatusCode == http.StatusNotFound {
+		return wrapNotFound(err, resp)
+	}
+	if resp.StatusCode != http.StatusForbidden {
+		return err
+	}
+
+	authErr := &AuthError{
+		Err:            err,
+		SSOURL:         ssoURL(resp.Header.Get("X-GitHub-SSO")),
+		AcceptedScopes: splitScopes(resp.Header.Get("X-Accepted-OAuth-Scopes")),
+		TokenScopes:    splitScopes(resp.Header.Get("X-OAuth-Scopes")),
+	}
+
+	if authErr.SSOURL == "" && len(authErr.MissingScopes()) == 0 {
+		return err
+	}
+
+	return authErr
+}
+
+// wrapNotFound explains a 404 for a repository as the token's doing when
+// it's a classic token without the repo scope. Fine-grained tokens don't
+// report what they can see, so their 404s are left as they are.
+func wrapNotFound(err error, resp *http.Response) error {
+	if resp.Request == nil || !strings.Contains(resp.Request.URL.Path, "/repos/") {
+		return err
+	}
+	if _, classic := resp.Header["X-Oauth-Scopes"]; !classic {
+		return err
+	}
+
+	scopes := splitScopes(resp.Header.Get("X-OAuth-Scopes"))
+	for _, s := range scopes {
+		if s == "repo" {
+			return err
+		}
+	}
+
+	return &AuthError{Err: err, AcceptedScopes: []string{"repo"}, TokenScopes: scopes, NotFound: true}
+}
+
+// TokenScopes asks GitHub which scopes the token was granted. The rate
+// limit endpoint answers without using up any of the quota.
+func (p *GitHubProvider) TokenScopes(ctx context.Context) ([]string, bool, error) {
+	resp, err := p.get(ctx, "token scopes", "rate_limit", nil)
+	if err != nil {
+		return nil, false, err
+	}
+	if _, classic := resp.Header["X-Oauth-Scopes"]; !classic {
+		return nil, false, nil
+	}
+	return splitScopes(resp.Header.Get("X-OAuth-Scopes")), true, nil
+}
+
+// ssoURL extracts the url from an "X-GitHub-SSO: required; url=..." header.
+func ssoURL(header string) string {
+	for _, part := range strings.Split(header, ";") {
+		part = strings.TrimSpace(part)
+		if strings.HasPrefix(part, "url=") {
+			return strings.TrimPrefix(part, "url=")
+		}
+	}
+	return ""
+}
+
+func splitScopes(header string) []string {
+	scopes := []string{}
+	for _, s := range strings.Split(header, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			scopes = append(scopes, s)
+		}
+	}
+	return scopes
+}
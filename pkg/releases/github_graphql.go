@@ -0,0 +1,178 @@
+package releases
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// WithGraphQL lists releases through the GraphQL API, 100 at a time with
+// their notes and assets, instead of REST. GraphQL needs a token; without
+// one, or if the token can't use it, listing falls back to REST.
+func (p *GitHubProvider) WithGraphQL(enabled bool) *GitHubProvider {
+	p.useGraphQL = enabled
+	return p
+}
+
+const releasesQuery = `query($owner: String!, $repo: String!, $after: String) {
+  repository(owner: $owner, name: $repo) {
+    releases(first: 100, after: $after, orderBy: {field: CREATED_AT, direction: DESC}) {
+      totalCount
+      pageInfo { hasNextPage endCursor }
+      nodes {
+        tagName
+        name
+        description
+        url
+        publishedAt
+        isPrerelease
+        isDraft
+        author { login }
+        reactions { totalCount }
+        discussion { url }
+        releaseAssets(first: 100) {
+          nodes { name downloadUrl size }
+        }
+      }
+    }
+  }
+}`
+
+type graphqlRelease struct {
+	TagName      string    `json:"tagName"`
+	Name         string    `json:"name"`
+	Description  string    `json:"description"`
+	URL          string    `json:"url"`
+	PublishedAt  time.Time `json:"publishedAt"`
+	IsPrerelease bool      `json:"isPrerelease"`
+	IsDraft      bool      `json:"isDraft"`
+	Author       *struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	Reactions struct {
+		TotalCount int `json:"totalCount"`
+	} `json:"reactions"`
+	Discussion *struct {
+		URL string `json:"url"`
+	} `json:"discussion"`
+	ReleaseAssets struct {
+		Nodes []struct {
+			Name        string `json:"name"`
+			DownloadURL string `json:"downloadUrl"`
+			Size        int    `json:"size"`
+		} `json:"nodes"`
+	} `json:"releaseAssets"`
+}
+
+// listReleasesGraphQL pages through every release. Pages follow each
+// other's cursors, so unlike REST they can't be fetched concurrently, but
+// there's one request per 100 releases either way and each one costs a
+// single point of the GraphQL quota.
+func (p *GitHubProvider) listReleasesGraphQL(ctx context.Context, owner, repo string, page func(list []Release, done, total int)) ([]Release, error) {
+	out := []Release{}
+	vars := map[string]interface{}{"owner": owner, "repo": repo, "after": nil}
+
+	for n := 1; ; n++ {
+		var data struct {
+			Repository *struct {
+				Releases struct {
+					TotalCount int `json:"totalCount"`
+					PageInfo   struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+					Nodes []graphqlRelease `json:"nodes"`
+				} `json:"releases"`
+			} `json:"repository"`
+		}
+
+		if n > 1 {
+			if err := p.budget.Background(ctx); err != nil {
+				return nil, err
+			}
+		}
+		if err := p.graphql(ctx, "ListReleases", releasesQuery, vars, &data); err != nil {
+			return nil, err
+		}
+		if data.Repository == nil {
+			return nil, errors.New("repository not found")
+		}
+
+		releases := data.Repository.Releases
+		list := make([]Release, len(releases.Nodes))
+		for i, r := range releases.Nodes {
+			list[i] = fromGraphQL(r)
+		}
+		out = append(out, list...)
+
+		page(list, n, max(n, (releases.TotalCount+99)/100))
+
+		if !releases.PageInfo.HasNextPage {
+			return out, nil
+		}
+		vars["after"] = releases.PageInfo.EndCursor
+	}
+}
+
+func fromGraphQL(r graphqlRelease) Release {
+	release := Release{
+		Tag:         r.TagName,
+		Name:        r.Name,
+		Description: r.Description,
+		URL:         r.URL,
+		Published:   r.PublishedAt,
+		Prerelease:  r.IsPrerelease,
+		Draft:       r.IsDraft,
+		Reactions:   r.Reactions.TotalCount,
+	}
+	if r.Author != nil {
+		release.Author = r.Author.Login
+	}
+	if r.Discussion != nil {
+		release.DiscussionURL = r.Discussion.URL
+	}
+
+	for _, a := range r.ReleaseAssets.Nodes {
+		release.Assets = append(release.Assets, Asset{Name: a.Name, URL: a.DownloadURL, Size: a.Size})
+	}
+
+	return release
+}
+
+// graphqlRefused reports whether err means the token can't use GraphQL at
+// all, so later listings shouldn't try it again.
+func graphqlRefused(err error) bool {
+	var ghErr *github.ErrorResponse
+	if !errors.As(err, &ghErr) || ghErr.Response == nil {
+		var authErr *AuthError
+		return errors.As(err, &authErr)
+	}
+	code := ghErr.Response.StatusCode
+	return code == http.StatusUnauthorized || code == http.StatusForbidden
+}
+
+// tryGraphQL lists releases through GraphQL when it's enabled, reporting
+// ok false when REST should be used instead.
+func (p *GitHubProvider) tryGraphQL(ctx context.Context, owner, repo string, page func(list []Release, done, total int)) ([]Release, bool, error) {
+	if !p.useGraphQL || p.graphqlOff.Load() {
+		return nil, false, nil
+	}
+
+	list, err := p.listReleasesGraphQL(ctx, owner, repo, page)
+	switch {
+	case err == nil:
+		return list, true, nil
+	case ctx.Err() != nil:
+		return nil, true, ctx.Err()
+	}
+
+	if graphqlRefused(err) {
+		p.graphqlOff.Store(true)
+	}
+	slog.Debug("graphql listing failed, falling back to REST", "err", err)
+	return nil, false, nil
+}
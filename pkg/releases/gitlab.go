@@ -0,0 +1,188 @@
+package releases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// GitLabProvider fetches releases through the GitLab REST API, from
+// gitlab.com or a self-hosted instance. GitLab's owner is the project's
+// namespace, which may be a nested group like "group/subgroup".
+type GitLabProvider struct {
+	client   *http.Client
+	baseURL  string
+	token    string
+	prefixes tagPrefixes
+}
+
+// DefaultGitLabURL is gitlab.com.
+const DefaultGitLabURL = "https://gitlab.com/"
+
+// NewGitLabProvider talks to the GitLab at baseURL (DefaultGitLabURL if
+// empty), authenticating with token unless it's empty.
+func NewGitLabProvider(client *http.Client, baseURL, token string) *GitLabProvider {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if baseURL == "" {
+		baseURL = DefaultGitLabURL
+	}
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+
+	return &GitLabProvider{client: client, baseURL: baseURL, token: token}
+}
+
+// WithTagPrefix browses only owner/repo's tags that start with prefix,
+// without it: the releases of one component of a monorepo.
+func (p *GitLabProvider) WithTagPrefix(owner, repo, prefix string) *GitLabProvider {
+	p.prefixes = p.prefixes.set(owner, repo, prefix)
+	return p
+}
+
+// WebURL is the root of the GitLab instance, with a trailing slash.
+func (p *GitLabProvider) WebURL() string {
+	return p.baseURL
+}
+
+// get fetches path below /api/v4/ into v, returning the next page number
+// from the pagination headers, or 0 on the last page.
+func (p *GitLabProvider) get(ctx context.Context, op, path string, v interface{}) (int, error) {
+	start := time.Now()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", p.baseURL+"api/v4/"+path, nil)
+	if err != nil {
+		return 0, err
+	}
+	if p.token != "" {
+		req.Header.Set("PRIVATE-TOKEN", p.token)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		slog.Debug("gitlab api call", "op", op, "path", path, "elapsed", time.Since(start), "err", err)
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	slog.Debug("gitlab api call", "op", op, "path", path, "elapsed", time.Since(start), "status", resp.StatusCode)
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return 0, statusError(resp.StatusCode, "GitLab project not found (or not visible to you): %s", path)
+	case resp.StatusCode == http.StatusUnauthorized:
+		return 0, statusError(resp.StatusCode, "GitLab rejected the token: %s", resp.Status)
+	case resp.StatusCode != http.StatusOK:
+		return 0, statusError(resp.StatusCode, "GitLab API %s: %s", path, resp.Status)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return 0, err
+	}
+
+	next := 0
+	fmt.Sscan(resp.Header.Get("X-Next-Page"), &next)
+	return next, nil
+}
+
+type gitlabRelease struct {
+	TagName     string    `json:"tag_name"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	ReleasedAt  time.Time `json:"released_at"`
+	Upcoming    bool      `json:"upcoming_release"`
+	Author      struct {
+		Username string `json:"username"`
+	} `json:"author"`
+	Links struct {
+		Self string `json:"self"`
+	} `json:"_links"`
+	Assets struct {
+		Links []struct {
+			Name           string `json:"name"`
+			URL            string `json:"url"`
+			DirectAssetURL string `json:"direct_asset_url"`
+		} `json:"links"`
+	} `json:"assets"`
+}
+
+func projectPath(owner, repo string) string {
+	return "projects/" + url.PathEscape(owner+"/"+repo)
+}
+
+func (p *GitLabProvider) ListReleases(ctx context.Context, owner, repo string) ([]Release, error) {
+	return p.ListReleasesByPage(ctx, owner, repo, nil)
+}
+
+// ListReleasesByPage follows the pages of releases one after the other,
+// handing each to page as it arrives. GitLab doesn't say how many there
+// are up front.
+func (p *GitLabProvider) ListReleasesByPage(ctx context.Context, owner, repo string, page func(list []Release, done, total int)) ([]Release, error) {
+	out := []Release{}
+
+	for n, done := 1, 0; n > 0; {
+		var list []gitlabRelease
+		path := fmt.Sprintf("%s/releases?per_page=100&page=%d", projectPath(owner, repo), n)
+
+		next, err := p.get(ctx, "ListReleases", path, &list)
+		if err != nil {
+			return nil, err
+		}
+
+		converted := make([]Release, len(list))
+		for i, r := range list {
+			converted[i] = fromGitLab(r)
+		}
+		converted = p.prefixes.strip(owner, repo, converted)
+		out = append(out, converted...)
+
+		done++
+		if page != nil {
+			page(converted, done, 0)
+		}
+		n = next
+	}
+
+	return out, nil
+}
+
+func (p *GitLabProvider) GetRelease(ctx context.Context, owner, repo, tag string) (Release, error) {
+	var r gitlabRelease
+	path := fmt.Sprintf("%s/releases/%s", projectPath(owner, repo), url.PathEscape(p.prefixes.full(owner, repo, tag)))
+	if _, err := p.get(ctx, "GetRelease", path, &r); err != nil {
+		return Release{}, err
+	}
+
+	release := fromGitLab(r)
+	release.Tag = tag
+	return release, nil
+}
+
+func fromGitLab(r gitlabRelease) Release {
+	release := Release{
+		Tag:         r.TagName,
+		Name:        r.Name,
+		Description: r.Description,
+		URL:         r.Links.Self,
+		Published:   r.ReleasedAt,
+		Prerelease:  r.Upcoming,
+		Author:      r.Author.Username,
+	}
+
+	for _, l := range r.Assets.Links {
+		link := l.DirectAssetURL
+		if link == "" {
+			link = l.URL
+		}
+		release.Assets = append(release.Assets, Asset{Name: l.Name, URL: link})
+	}
+
+	return release
+}
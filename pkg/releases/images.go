@@ -0,0 +1,70 @@
+package releases
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+// Image is a picture embedded in release notes, like a screenshot.
+type Image struct {
+	Alt string
+	URL string
+}
+
+var (
+	// markdownImageRe matches ![alt](url "title").
+	markdownImageRe = regexp.MustCompile(`!\[([^\]]*)\]\(([^)\s]+)(?:\s+"[^"]*")?\)`)
+	// htmlImageRe matches <img> tags, which GitHub's editor inserts for
+	// pasted screenshots; imgAttrRe picks out their attributes.
+	htmlImageRe = regexp.MustCompile(`(?i)<img\s[^>]*>`)
+	imgAttrRe   = regexp.MustCompile(`(?i)\b(src|alt)\s*=\s*("[^"]*"|'[^']*'|[^\s>]+)`)
+)
+
+// Images returns the images embedded in body: its markdown images, then
+// its <img> tags.
+func Images(body string) []Image {
+	images := []Image{}
+	replaceImages(body, func(img Image) string {
+		images = append(images, img)
+		return ""
+	})
+	return images
+}
+
+// MarkImages replaces the images embedded in body, which a terminal can't
+// show in line with text, with a placeholder naming each one and linking
+// it.
+func MarkImages(body string) string {
+	return replaceImages(body, func(img Image) string {
+		alt := strings.NewReplacer("[", "(", "]", ")").Replace(img.Alt)
+		if alt == "" {
+			alt = "image"
+		}
+		return "🖼 [" + alt + "](" + img.URL + ")"
+	})
+}
+
+// replaceImages replaces each image in body with what with returns.
+func replaceImages(body string, with func(Image) string) string {
+	body = markdownImageRe.ReplaceAllStringFunc(body, func(s string) string {
+		m := markdownImageRe.FindStringSubmatch(s)
+		return with(Image{Alt: m[1], URL: m[2]})
+	})
+
+	return htmlImageRe.ReplaceAllStringFunc(body, func(s string) string {
+		img := Image{}
+		for _, attr := range imgAttrRe.FindAllStringSubmatch(s, -1) {
+			value := html.UnescapeString(strings.Trim(attr[2], `"'`))
+			if strings.EqualFold(attr[1], "src") {
+				img.URL = value
+			} else {
+				img.Alt = value
+			}
+		}
+		if img.URL == "" {
+			return s
+		}
+		return with(img)
+	})
+}
@@ -0,0 +1,96 @@
+package releases
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// IssueRef is a reference to an issue or pull request found in release
+// notes.
+type IssueRef struct {
+	Owner  string
+	Repo   string
+	Number int
+}
+
+func (r IssueRef) String() string {
+	return fmt.Sprintf("%s/%s#%d", r.Owner, r.Repo, r.Number)
+}
+
+// Issue is what an issue or pull request is about.
+type Issue struct {
+	Title  string
+	State  string // open or closed
+	Labels []string
+	// PullRequest is set for pull requests, which are issues to the API.
+	PullRequest bool
+}
+
+// IssueGetter is implemented by providers that can look up issues and
+// pull requests.
+type IssueGetter interface {
+	GetIssue(ctx context.Context, ref IssueRef) (Issue, error)
+}
+
+var (
+	issueURLRe = regexp.MustCompile(`https://[\w.-]+(?::\d+)?/([\w.-]+)/([\w.-]+)/(?:pull|issues)/(\d+)`)
+	// "#1234" and "owner/repo#1234", but not "&#1234;" or a URL's fragment
+	issueRefRe = regexp.MustCompile(`(?:^|[^\w/#&])(?:([\w.-]+)/([\w.-]+))?#(\d+)\b`)
+)
+
+// IssueLinks returns the distinct issues and pull requests line refers
+// to, by URL or by number, in order. Bare numbers are in owner/repo.
+func IssueLinks(line, owner, repo string) []IssueRef {
+	seen := map[IssueRef]bool{}
+	refs := []IssueRef{}
+
+	add := func(o, r, number string) {
+		n, err := strconv.Atoi(number)
+		if err != nil {
+			return
+		}
+		ref := IssueRef{Owner: o, Repo: r, Number: n}
+		if !seen[ref] {
+			seen[ref] = true
+			refs = append(refs, ref)
+		}
+	}
+
+	for _, m := range issueURLRe.FindAllStringSubmatch(line, -1) {
+		add(m[1], m[2], m[3])
+	}
+
+	for _, m := range issueRefRe.FindAllStringSubmatch(issueURLRe.ReplaceAllString(line, ""), -1) {
+		if m[1] == "" {
+			add(owner, repo, m[3])
+		} else {
+			add(m[1], m[2], m[3])
+		}
+	}
+
+	return refs
+}
+
+// GetIssue is enrichment, and waits its turn in the budget.
+func (p *GitHubProvider) GetIssue(ctx context.Context, ref IssueRef) (Issue, error) {
+	if err := p.budget.Background(ctx); err != nil {
+		return Issue{}, err
+	}
+
+	var i github.Issue
+	path := fmt.Sprintf("repos/%s/%s/issues/%d", ref.Owner, ref.Repo, ref.Number)
+	if _, err := p.get(ctx, "GetIssue", path, &i); err != nil {
+		return Issue{}, err
+	}
+
+	issue := Issue{Title: i.GetTitle(), State: i.GetState(), PullRequest: i.IsPullRequest()}
+	for _, l := range i.Labels {
+		issue.Labels = append(issue.Labels, l.GetName())
+	}
+
+	return issue, nil
+}
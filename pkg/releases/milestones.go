@@ -0,0 +1,84 @@
+package releases
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// MilestoneRef is a link to a milestone found in release notes.
+type MilestoneRef struct {
+	Owner  string
+	Repo   string
+	Number int
+	URL    string
+}
+
+func (r MilestoneRef) String() string {
+	return fmt.Sprintf("%s/%s#milestone-%d", r.Owner, r.Repo, r.Number)
+}
+
+// Milestone is a milestone's title and how much of it is done.
+type Milestone struct {
+	Title  string
+	Open   int
+	Closed int
+	State  string
+}
+
+// Percent is the share of the milestone's issues that are closed.
+func (m Milestone) Percent() int {
+	if m.Open+m.Closed == 0 {
+		return 0
+	}
+	return m.Closed * 100 / (m.Open + m.Closed)
+}
+
+// MilestoneGetter is implemented by providers that can look up
+// milestones.
+type MilestoneGetter interface {
+	GetMilestone(ctx context.Context, ref MilestoneRef) (Milestone, error)
+}
+
+var milestoneRe = regexp.MustCompile(`https://[\w.-]+(?::\d+)?/([\w.-]+)/([\w.-]+)/milestone/(\d+)`)
+
+// MilestoneLinks returns the distinct milestone links in body, in order.
+func MilestoneLinks(body string) []MilestoneRef {
+	seen := map[string]bool{}
+	refs := []MilestoneRef{}
+
+	for _, m := range milestoneRe.FindAllStringSubmatch(body, -1) {
+		if seen[m[0]] {
+			continue
+		}
+		seen[m[0]] = true
+
+		n, _ := strconv.Atoi(m[3])
+		refs = append(refs, MilestoneRef{Owner: m[1], Repo: m[2], Number: n, URL: m[0]})
+	}
+
+	return refs
+}
+
+// GetMilestone is enrichment, and waits its turn in the budget.
+func (p *GitHubProvider) GetMilestone(ctx context.Context, ref MilestoneRef) (Milestone, error) {
+	if err := p.budget.Background(ctx); err != nil {
+		return Milestone{}, err
+	}
+
+	var m github.Milestone
+	path := fmt.Sprintf("repos/%s/%s/milestones/%d", ref.Owner, ref.Repo, ref.Number)
+	if _, err := p.get(ctx, "GetMilestone", path, &m); err != nil {
+		return Milestone{}, err
+	}
+
+	return Milestone{
+		Title:  m.GetTitle(),
+		Open:   m.GetOpenIssues(),
+		Closed: m.GetClosedIssues(),
+		State:  m.GetState(),
+	}, nil
+}
@@ -0,0 +1,252 @@
+package releases
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Contributor is someone credited in release notes.
+type Contributor struct {
+	Login string
+	// FirstPR links the pull request a new contributor's first
+	// contribution was made in, when the notes say.
+	FirstPR string
+	// Release is the tag whose notes first mentioned them.
+	Release string
+	// Contributions counts the "by @login" entries crediting them.
+	Contributions int
+}
+
+var (
+	newContributorRe = regexp.MustCompile(`@([A-Za-z0-9][A-Za-z0-9-]*(?:\[bot\])?) made their first contribution(?: in (\S+))?`)
+	creditRe         = regexp.MustCompile(`\bby @([A-Za-z0-9][A-Za-z0-9-]*(?:\[bot\])?)`)
+	mentionRe        = regexp.MustCompile(`(?:^|[^\w@/.])@([A-Za-z0-9][A-Za-z0-9-]*)`)
+)
+
+// NewContributors returns the people listed under the "New Contributors"
+// heading GitHub's generated notes include, in the order listed.
+func NewContributors(body string) []Contributor {
+	people := []Contributor{}
+
+	for _, line := range section(body, "new contributors") {
+		if m := newContributorRe.FindStringSubmatch(line); m != nil {
+			people = append(people, Contributor{Login: m[1], FirstPR: m[2]})
+		}
+	}
+
+	return people
+}
+
+// Credits counts "by @login" mentions, as in generated "What's Changed"
+// entries.
+func Credits(body string) map[string]int {
+	counts := make(map[string]int)
+	for _, m := range creditRe.FindAllStringSubmatch(body, -1) {
+		counts[m[1]]++
+	}
+	return counts
+}
+
+// Mentions counts every @login mentioned in body.
+func Mentions(body string) map[string]int {
+	counts := make(map[string]int)
+	for _, m := range mentionRe.FindAllStringSubmatch(body, -1) {
+		counts[m[1]]++
+	}
+	return counts
+}
+
+var breakingHeadingRe = regexp.MustCompile(`(?i)breaking|upgrade notes|upgrading|migration`)
+
+// BreakingHeading returns the text of the first heading in body that
+// introduces breaking changes or upgrade notes, or "".
+func BreakingHeading(body string) string {
+	for _, line := range strings.Split(body, "\n") {
+		if headingLevel(line) > 0 && breakingHeadingRe.MatchString(line) {
+			return strings.TrimSpace(strings.TrimLeft(line, "#"))
+		}
+	}
+	return ""
+}
+
+// breakingMarkerRe matches a conventional commit marked breaking, like
+// "feat!: drop Go 1.19" or "fix(api)!: ...", and BREAKING CHANGE notes.
+var breakingMarkerRe = regexp.MustCompile(`^\s*(?:[-*+]\s+)?(?:\*\*)?\w+(?:\([^)]*\))?!:|\bBREAKING\b`)
+
+// IsBreaking reports whether body announces breaking changes: under a
+// heading BreakingHeading finds, or in an entry marked breaking.
+func IsBreaking(body string) bool {
+	if BreakingHeading(body) != "" {
+		return true
+	}
+	for _, line := range strings.Split(body, "\n") {
+		if breakingMarkerRe.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
+
+const breakingMark = "⚠ "
+
+// MarkBreaking flags the breaking change headings and entries in body
+// with a warning sign, and makes the entries bold, so they stand out once
+// rendered.
+func MarkBreaking(body string) string {
+	lines := strings.Split(body, "\n")
+
+	for i, line := range lines {
+		if level := headingLevel(line); level > 0 {
+			if breakingHeadingRe.MatchString(line) {
+				lines[i] = line[:level+1] + breakingMark + strings.TrimLeft(line[level+1:], " ")
+			}
+			continue
+		}
+		if !breakingMarkerRe.MatchString(line) {
+			continue
+		}
+
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		text := strings.TrimLeft(line, " \t")
+		bullet := ""
+		if len(text) > 1 && strings.ContainsRune("-*+", rune(text[0])) && text[1] == ' ' {
+			bullet, text = text[:2], strings.TrimLeft(text[2:], " ")
+		}
+		if !strings.Contains(text, "**") {
+			text = "**" + text + "**"
+		}
+		lines[i] = indent + bullet + breakingMark + text
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+var (
+	deprecationHeadingRe = regexp.MustCompile(`(?i)deprecat|end of life|\beol\b|sunset|removals?\b`)
+	deprecationRe        = regexp.MustCompile(`(?i)\bdeprecat|\bend[- ]of[- ]life\b|\bEOL\b|\bno longer supported\b|\bwill be removed\b`)
+)
+
+// Deprecations lists the deprecation and end-of-life notices in body:
+// every entry under a heading about them, and any other line that
+// announces one, in the order they appear.
+func Deprecations(body string) []string {
+	notices := []string{}
+	seen := map[string]bool{}
+
+	under := 0 // the level of the deprecations heading we're under
+	for _, line := range strings.Split(body, "\n") {
+		if level := headingLevel(line); level > 0 {
+			switch {
+			case deprecationHeadingRe.MatchString(line):
+				under = level
+			case under > 0 && level <= under:
+				under = 0
+			}
+			continue
+		}
+
+		text := strings.TrimSpace(line)
+		if len(text) > 1 && strings.ContainsRune("-*+", rune(text[0])) && text[1] == ' ' {
+			text = strings.TrimSpace(text[2:])
+		}
+		if text == "" || strings.HasPrefix(text, "```") || seen[text] || under == 0 && !deprecationRe.MatchString(text) {
+			continue
+		}
+
+		seen[text] = true
+		notices = append(notices, text)
+	}
+
+	return notices
+}
+
+// Section is a heading in release notes, with the lines under it up to
+// the next heading of the same or a higher level.
+type Section struct {
+	Title string
+	Level int
+	// Start is the line of the heading, End the line after the section.
+	Start, End int
+}
+
+// dependencyHeadingRe matches the headings of sections listing dependency
+// updates, like "Dependencies" or "⬆️ Bumps".
+var dependencyHeadingRe = regexp.MustCompile(`(?i)dependenc|\bdeps\b|\bbumps\b`)
+
+// Sections lists the headings in body in the order they appear. Lines in
+// code blocks aren't headings, however many #s they start with.
+func Sections(body string) []Section {
+	lines := strings.Split(body, "\n")
+	sections := []Section{}
+
+	fenced := false
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			fenced = !fenced
+			continue
+		}
+		level := headingLevel(line)
+		if fenced || level == 0 {
+			continue
+		}
+
+		// this heading ends the sections it isn't nested in
+		for j := range sections {
+			if sections[j].End == 0 && sections[j].Level >= level {
+				sections[j].End = i
+			}
+		}
+		sections = append(sections, Section{
+			Title: strings.TrimSpace(strings.TrimRight(strings.TrimLeft(line, "#"), "# ")),
+			Level: level,
+			Start: i,
+		})
+	}
+
+	for j := range sections {
+		if sections[j].End == 0 {
+			sections[j].End = len(lines)
+		}
+	}
+	return sections
+}
+
+// IsDependencies reports whether a section lists dependency updates.
+func (s Section) IsDependencies() bool {
+	return dependencyHeadingRe.MatchString(s.Title)
+}
+
+// section returns the lines under the first markdown heading whose text
+// contains title (case-insensitively), up to the next heading of the same
+// or higher level.
+func section(body, title string) []string {
+	lines := strings.Split(body, "\n")
+
+	for i, line := range lines {
+		level := headingLevel(line)
+		if level == 0 || !strings.Contains(strings.ToLower(line), title) {
+			continue
+		}
+
+		end := len(lines)
+		for j := i + 1; j < len(lines); j++ {
+			if l := headingLevel(lines[j]); l > 0 && l <= level {
+				end = j
+				break
+			}
+		}
+
+		return lines[i+1 : end]
+	}
+
+	return nil
+}
+
+func headingLevel(line string) int {
+	trimmed := strings.TrimLeft(line, "#")
+	level := len(line) - len(trimmed)
+	if level == 0 || level > 6 || !strings.HasPrefix(trimmed, " ") {
+		return 0
+	}
+	return level
+}
@@ -0,0 +1,115 @@
+package releases
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIsBreaking(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want bool
+	}{
+		{"breaking heading", "## Breaking Changes\n- dropped Go 1.19", true},
+		{"upgrade notes heading", "### Upgrade notes\nrun the migration", true},
+		{"conventional commit marker", "## What's Changed\n- feat!: drop Go 1.19", true},
+		{"scoped marker", "* fix(api)!: rename Client", true},
+		{"BREAKING CHANGE note", "BREAKING CHANGE: config keys renamed", true},
+		{"ordinary notes", "## Fixes\n- feat: add a flag\n- breaking the loop early", false},
+		{"heading text without a heading", "no breaking changes here... well, lowercase", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsBreaking(tt.body); got != tt.want {
+				t.Errorf("IsBreaking() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkBreaking(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"heading", "## Breaking changes", "## ⚠ Breaking changes"},
+		{"entry", "- feat!: drop Go 1.19", "- ⚠ **feat!: drop Go 1.19**"},
+		{"indented entry", "  * fix(api)!: rename", "  * ⚠ **fix(api)!: rename**"},
+		{"already bold", "**BREAKING**: renamed", "⚠ **BREAKING**: renamed"},
+		{"untouched", "## Fixes\n- fix: typo", "## Fixes\n- fix: typo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MarkBreaking(tt.body); got != tt.want {
+				t.Errorf("MarkBreaking() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeprecations(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{
+			name: "under a heading",
+			body: "## Deprecations\n- `Foo` is going away\n- `Bar` too\n## Fixes\n- fix: typo",
+			want: []string{"`Foo` is going away", "`Bar` too"},
+		},
+		{
+			name: "nested headings stay under it",
+			body: "## Removals\n### API\n- old endpoint\n## Other\n- nothing",
+			want: []string{"old endpoint"},
+		},
+		{
+			name: "inline notices, deduplicated",
+			body: "- Node 14 is no longer supported\n- fix: a bug\n- `--foo` is deprecated\n- Node 14 is no longer supported",
+			want: []string{"Node 14 is no longer supported", "`--foo` is deprecated"},
+		},
+		{
+			name: "code fences skipped",
+			body: "## Deprecated\n```\nold()\n```",
+			want: []string{"old()"},
+		},
+		{
+			name: "none",
+			body: "## Features\n- new flag",
+			want: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Deprecations(tt.body); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Deprecations() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSections(t *testing.T) {
+	body := "# v1.2.0\n## Features\n- a\n### Details\nmore\n## Dependencies\n- bump x\n```\n# not a heading\n```\n"
+
+	want := []Section{
+		{Title: "v1.2.0", Level: 1, Start: 0, End: 11},
+		{Title: "Features", Level: 2, Start: 1, End: 5},
+		{Title: "Details", Level: 3, Start: 3, End: 5},
+		{Title: "Dependencies", Level: 2, Start: 5, End: 11},
+	}
+	got := Sections(body)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Sections() = %+v, want %+v", got, want)
+	}
+
+	for i, dep := range []bool{false, false, false, true} {
+		if got[i].IsDependencies() != dep {
+			t.Errorf("%q IsDependencies() = %v, want %v", got[i].Title, !dep, dep)
+		}
+	}
+}
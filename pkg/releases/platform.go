@@ -0,0 +1,109 @@
+package releases
+
+import (
+	"regexp"
+	"strings"
+)
+
+// platformPatterns recognize OS and architecture names in asset file
+// names, following the conventions of goreleaser, cargo-dist and the
+// like. Architectures are tried in order, so x86_64 isn't taken for x86.
+var (
+	osPatterns = []struct {
+		goos string
+		re   *regexp.Regexp
+	}{
+		{"darwin", wordRe(`darwin|macos|mac|osx|apple`)},
+		{"windows", wordRe(`windows|win|win32|win64|pc-windows-msvc|pc-windows-gnu`)},
+		{"linux", wordRe(`linux|unknown-linux-gnu|unknown-linux-musl`)},
+		{"freebsd", wordRe(`freebsd`)},
+	}
+
+	archPatterns = []struct {
+		goarch string
+		re     *regexp.Regexp
+	}{
+		{"amd64", wordRe(`amd64|x86_64|x86-64|x64|64bit`)},
+		{"arm64", wordRe(`arm64|aarch64|armv8`)},
+		{"386", wordRe(`386|i386|i686|x86|32bit`)},
+		{"arm", wordRe(`armv7|armv7l|armv6|armhf|arm`)},
+		{"all", wordRe(`universal|all`)},
+	}
+
+	// sidecarRe matches the files published alongside assets: checksums,
+	// signatures, SBOMs and the like.
+	sidecarRe = regexp.MustCompile(`(?i)(\.(sig|pem|crt|asc|sha256|sha512|sbom|spdx|json|intoto\.jsonl|bundle|txt)$)|checksums|sha256sums`)
+
+	// packageRe matches OS packages, which are only picked when there's no
+	// plain archive or binary.
+	packageRe = regexp.MustCompile(`(?i)\.(deb|rpm|apk|msi|pkg|dmg)$`)
+)
+
+func wordRe(alternatives string) *regexp.Regexp {
+	return regexp.MustCompile(`(?i)(^|[^a-z0-9])(` + alternatives + `)([^a-z0-9]|$)`)
+}
+
+// Platform reports the OS and architecture an asset is built for, as
+// GOOS/GOARCH values, or "" for either when the name doesn't say.
+// An architecture of "all" marks universal binaries.
+func Platform(name string) (goos, goarch string) {
+	for _, p := range osPatterns {
+		if p.re.MatchString(name) {
+			goos = p.goos
+			break
+		}
+	}
+
+	for _, p := range archPatterns {
+		if p.re.MatchString(name) {
+			goarch = p.goarch
+			break
+		}
+	}
+
+	if goos == "windows" && goarch == "" && strings.HasSuffix(strings.ToLower(name), ".exe") {
+		goarch = "amd64"
+	}
+
+	return goos, goarch
+}
+
+// MatchPlatform returns the assets built for goos/goarch, best first:
+// archives and binaries ahead of OS packages, exact architectures ahead of
+// universal builds. Checksums and signatures are never returned. When
+// more than one asset shares the best rank the choice is ambiguous, and
+// ok is false.
+func MatchPlatform(assets []Asset, goos, goarch string) (matches []Asset, ok bool) {
+	best := -1
+	ranked := map[int][]Asset{}
+
+	for _, a := range assets {
+		if sidecarRe.MatchString(a.Name) {
+			continue
+		}
+
+		os, arch := Platform(a.Name)
+		if os != goos || (arch != goarch && arch != "all") {
+			continue
+		}
+
+		rank := 0
+		if packageRe.MatchString(a.Name) {
+			rank += 2
+		}
+		if arch == "all" {
+			rank++
+		}
+
+		ranked[rank] = append(ranked[rank], a)
+		if best < 0 || rank < best {
+			best = rank
+		}
+	}
+
+	for rank := 0; rank <= 3; rank++ {
+		matches = append(matches, ranked[rank]...)
+	}
+
+	return matches, best >= 0 && len(ranked[best]) == 1
+}
@@ -0,0 +1,44 @@
+package releases
+
+import "strings"
+
+// tagPrefixes maps owner/repo to the prefix its version tags carry, in
+// repositories that release several components side by side, like
+// "cli-v1.2.3" or "sdk/v1.2.3". Listings keep only the tags with the
+// prefix, and show them without it; lookups by tag put it back.
+type tagPrefixes map[string]string
+
+func (t tagPrefixes) set(owner, repo, prefix string) tagPrefixes {
+	if t == nil {
+		t = tagPrefixes{}
+	}
+	if prefix == "" {
+		delete(t, owner+"/"+repo)
+	} else {
+		t[owner+"/"+repo] = prefix
+	}
+	return t
+}
+
+// strip drops the releases of other components from list, and the prefix
+// from the tags of the rest.
+func (t tagPrefixes) strip(owner, repo string, list []Release) []Release {
+	prefix := t[owner+"/"+repo]
+	if prefix == "" {
+		return list
+	}
+
+	out := make([]Release, 0, len(list))
+	for _, r := range list {
+		if tag, ok := strings.CutPrefix(r.Tag, prefix); ok {
+			r.Tag = tag
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// full is tag as the repository has it.
+func (t tagPrefixes) full(owner, repo, tag string) string {
+	return t[owner+"/"+repo] + tag
+}
@@ -0,0 +1,105 @@
+package releases
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// Release is a single published release of a repository.
+type Release struct {
+	Tag string
+	// Name is the release's title, which often differs from its tag.
+	Name        string
+	Description string
+
+	// URL is the release's web page.
+	URL string
+	// DiscussionURL links the release's discussion thread, if it has one.
+	DiscussionURL string
+	// Published is when the release was published; zero if unknown.
+	Published time.Time
+	// Author is the login of whoever published the release.
+	Author string
+	// Reactions totals the emoji reactions on the release; GitLab doesn't
+	// have them.
+	Reactions int
+	// Prerelease is set when the forge marks the release as a prerelease.
+	Prerelease bool
+	// Draft is set for releases that haven't been published yet, which
+	// only collaborators can see.
+	Draft  bool
+	Assets []Asset
+}
+
+// Asset is a file attached to a release.
+type Asset struct {
+	Name string
+	// URL downloads the asset.
+	URL  string
+	Size int
+}
+
+// Provider fetches the releases of a repository from some forge.
+type Provider interface {
+	ListReleases(ctx context.Context, owner, repo string) ([]Release, error)
+}
+
+// Getter is implemented by providers that can fetch a single release by
+// tag, letting callers drop release bodies from memory and re-fetch them
+// when they're needed again.
+type Getter interface {
+	GetRelease(ctx context.Context, owner, repo, tag string) (Release, error)
+}
+
+// FileGetter is implemented by providers that can fetch a file from a
+// repository as of a tag, for following links in release notes to the
+// documents beside them.
+type FileGetter interface {
+	GetFile(ctx context.Context, owner, repo, tag, path string) (string, error)
+}
+
+// ScopeChecker is implemented by providers whose tokens carry scopes. ok
+// is false for tokens without them, like fine-grained ones, whose access
+// is granted repository by repository instead.
+type ScopeChecker interface {
+	TokenScopes(ctx context.Context) (scopes []string, ok bool, err error)
+}
+
+// ProgressLister is implemented by providers that fetch releases in
+// several requests and can report how far along they are.
+type ProgressLister interface {
+	ListReleasesWithProgress(ctx context.Context, owner, repo string, progress func(done, total int)) ([]Release, error)
+}
+
+// PageLister is implemented by providers that can hand over each page of
+// releases as it arrives, so they can be shown before the rest are in.
+// total is 0 when the forge doesn't say how many pages there are.
+type PageLister interface {
+	ListReleasesByPage(ctx context.Context, owner, repo string, page func(list []Release, done, total int)) ([]Release, error)
+}
+
+// Stable reports whether the release is a final one: not a draft, not
+// marked as a prerelease, and not tagged with a semver prerelease.
+func (r Release) Stable() bool {
+	return !r.Draft && !r.Prerelease && !IsPrerelease(r.Tag)
+}
+
+// Title is the release's tag, followed by its name when the project gave
+// it one of its own, as in "v2.3.0 – Performance edition".
+func (r Release) Title() string {
+	name := strings.TrimSpace(r.Name)
+	if name == "" || name == r.Tag || strings.TrimPrefix(name, "v") == strings.TrimPrefix(r.Tag, "v") {
+		return r.Tag
+	}
+	return r.Tag + " – " + name
+}
+
+// Matches reports whether the release's tag, title or notes contain query,
+// ignoring case.
+func (r Release) Matches(query string) bool {
+	q := strings.ToLower(query)
+	return strings.Contains(strings.ToLower(r.Tag), q) ||
+		strings.Contains(strings.ToLower(r.Name), q) ||
+		strings.Contains(strings.ToLower(r.Description), q)
+}
@@ -0,0 +1,80 @@
+package releases
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+
+	"github.com/masterminds/semver"
+)
+
+// SortedTags parses tags as semantic versions and returns them in
+// ascending order, leaving out tags that aren't versions.
+func SortedTags(tags []string) semver.Collection {
+	tagList, _ := SplitTags(tags)
+	return tagList
+}
+
+// SplitTags is SortedTags, also returning the tags that don't parse as
+// semantic versions, like "list-1.0" or "nightly", sorted by name.
+func SplitTags(tags []string) (semver.Collection, []string) {
+	tagList := make([]*semver.Version, 0, len(tags))
+	other := []string{}
+
+	for _, t := range tags {
+		v, err := semver.NewVersion(t)
+		if err != nil {
+			slog.Debug("tag is not a semantic version", "tag", t, "err", err)
+			other = append(other, t)
+			continue
+		}
+
+		tagList = append(tagList, v)
+	}
+
+	sort.Sort(semver.Collection(tagList))
+	sort.Strings(other)
+
+	return tagList, other
+}
+
+// FindTagIndex returns the index of the first tag in tagList after current.
+func FindTagIndex(current *semver.Version, tagList semver.Collection) (int, error) {
+	// return next semver tag after current
+	for i := range tagList {
+		if tagList[i].GreaterThan(current) {
+			return i, nil
+		}
+	}
+
+	return -1, fmt.Errorf("Could not find version after v%s", current)
+}
+
+// ParseConstraint reads a constraint on versions, like "^1.4" or
+// ">=2, <3". A plain version isn't one.
+func ParseConstraint(s string) (*semver.Constraints, bool) {
+	if _, err := semver.NewVersion(s); err == nil || s == "" {
+		return nil, false
+	}
+	c, err := semver.NewConstraint(s)
+	return c, err == nil
+}
+
+func IsMajor(v *semver.Version) bool {
+	return v.Minor() == 0 && v.Patch() == 0 && v.Prerelease() == ""
+}
+
+func IsMinor(v *semver.Version) bool {
+	return v.Minor() != 0 && v.Patch() == 0 && v.Prerelease() == ""
+}
+
+func IsPatch(v *semver.Version) bool {
+	return v.Patch() != 0 && v.Prerelease() == ""
+}
+
+// IsPrerelease reports whether tag is a semantic version with a prerelease
+// part, like 1.2.0-rc.1.
+func IsPrerelease(tag string) bool {
+	v, err := semver.NewVersion(tag)
+	return err == nil && v.Prerelease() != ""
+}
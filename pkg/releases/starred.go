@@ -0,0 +1,153 @@
+package releases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// Starred is a repository the user starred, with its latest release.
+type Starred struct {
+	Repo string // owner/repo
+	// LatestTag is empty when the repository has no releases.
+	LatestTag string
+	Published time.Time
+}
+
+// StarLister is implemented by providers that can list the authenticated
+// user's starred repositories.
+type StarLister interface {
+	ListStarred(ctx context.Context) ([]Starred, error)
+}
+
+const starredQuery = `query($after: String) {
+  viewer {
+    starredRepositories(first: 100, after: $after, orderBy: {field: STARRED_AT, direction: DESC}) {
+      pageInfo { hasNextPage endCursor }
+      nodes {
+        nameWithOwner
+        latestRelease { tagName publishedAt }
+      }
+    }
+  }
+}`
+
+// ListStarred lists the token owner's starred repositories, most recently
+// starred first. Through GraphQL that's one request per 100 with their
+// latest releases; through REST each latest release is a request of its
+// own.
+func (p *GitHubProvider) ListStarred(ctx context.Context) ([]Starred, error) {
+	if p.useGraphQL && !p.graphqlOff.Load() {
+		list, err := p.listStarredGraphQL(ctx)
+		if err == nil || ctx.Err() != nil {
+			return list, err
+		}
+		if graphqlRefused(err) {
+			p.graphqlOff.Store(true)
+		}
+	}
+
+	return p.listStarredREST(ctx)
+}
+
+func (p *GitHubProvider) listStarredGraphQL(ctx context.Context) ([]Starred, error) {
+	out := []Starred{}
+	vars := map[string]interface{}{"after": nil}
+
+	for {
+		var data struct {
+			Viewer struct {
+				StarredRepositories struct {
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+					Nodes []struct {
+						NameWithOwner string `json:"nameWithOwner"`
+						LatestRelease *struct {
+							TagName     string    `json:"tagName"`
+							PublishedAt time.Time `json:"publishedAt"`
+						} `json:"latestRelease"`
+					} `json:"nodes"`
+				} `json:"starredRepositories"`
+			} `json:"viewer"`
+		}
+
+		if err := p.graphql(ctx, "ListStarred", starredQuery, vars, &data); err != nil {
+			return nil, err
+		}
+
+		starred := data.Viewer.StarredRepositories
+		for _, r := range starred.Nodes {
+			s := Starred{Repo: r.NameWithOwner}
+			if r.LatestRelease != nil {
+				s.LatestTag, s.Published = r.LatestRelease.TagName, r.LatestRelease.PublishedAt
+			}
+			out = append(out, s)
+		}
+
+		if !starred.PageInfo.HasNextPage {
+			return out, nil
+		}
+		vars["after"] = starred.PageInfo.EndCursor
+	}
+}
+
+func (p *GitHubProvider) listStarredREST(ctx context.Context) ([]Starred, error) {
+	out := []Starred{}
+
+	for page := 1; page != 0; {
+		var list []*github.Repository
+		resp, err := p.get(ctx, "ListStarred", fmt.Sprintf("user/starred?per_page=100&page=%d", page), &list)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range list {
+			out = append(out, Starred{Repo: r.GetFullName()})
+		}
+		page = resp.NextPage
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	sem := make(chan struct{}, pageConcurrency)
+	for i := range out {
+		wg.Add(1)
+		go func(s *Starred) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			var latest github.RepositoryRelease
+			_, err := p.get(ctx, "GetLatestRelease", fmt.Sprintf("repos/%s/releases/latest", s.Repo), &latest)
+			if err != nil {
+				// no releases at all is a 404
+				var ghErr *github.ErrorResponse
+				if errors.As(err, &ghErr) && ghErr.Response != nil && ghErr.Response.StatusCode == http.StatusNotFound {
+					return
+				}
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+				return
+			}
+			s.LatestTag, s.Published = latest.GetTagName(), latest.GetPublishedAt().Time
+		}(&out[i])
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return out, nil
+}
@@ -0,0 +1,34 @@
+package releases
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/google/go-github/v48/github"
+)
+
+// User is a forge account.
+type User struct {
+	Login string
+	Name  string
+}
+
+// UserGetter is implemented by providers that can look up accounts.
+type UserGetter interface {
+	GetUser(ctx context.Context, login string) (User, error)
+}
+
+// GetUser is enrichment, and waits its turn in the budget.
+func (p *GitHubProvider) GetUser(ctx context.Context, login string) (User, error) {
+	if err := p.budget.Background(ctx); err != nil {
+		return User{}, err
+	}
+
+	var u github.User
+	if _, err := p.get(ctx, "GetUser", fmt.Sprintf("users/%s", url.PathEscape(login)), &u); err != nil {
+		return User{}, err
+	}
+
+	return User{Login: u.GetLogin(), Name: u.GetName()}, nil
+}
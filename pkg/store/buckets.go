@@ -0,0 +1,80 @@
+package store
+
+import "encoding/json"
+
+// Buckets used by brows. Portable lists the ones that describe the user's
+// own state, as opposed to data that can be re-fetched or is tied to a
+// machine.
+const (
+	BucketReviews   = "reviews"
+	BucketHistory   = "history"
+	BucketWatch     = "watch"
+	BucketIdentity  = "identity"
+	BucketPins      = "pins"
+	BucketResponses = "responses"
+)
+
+var Portable = []string{BucketReviews, BucketHistory, BucketWatch, BucketPins}
+
+// Snapshot is the exported form of a set of buckets.
+type Snapshot struct {
+	Version int                                   `json:"version"`
+	Buckets map[string]map[string]json.RawMessage `json:"buckets"`
+}
+
+const snapshotVersion = 1
+
+// Export copies the named buckets into a Snapshot.
+func (s *Store) Export(buckets []string) (Snapshot, error) {
+	snap := Snapshot{Version: snapshotVersion, Buckets: make(map[string]map[string]json.RawMessage)}
+	for _, b := range buckets {
+		rows, err := s.db.Query(`SELECT key, value FROM kv WHERE bucket = ?`, b)
+		if err != nil {
+			return snap, err
+		}
+
+		copied := make(map[string]json.RawMessage)
+		for rows.Next() {
+			var k string
+			var v []byte
+			if err := rows.Scan(&k, &v); err != nil {
+				rows.Close()
+				return snap, err
+			}
+			copied[k] = v
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return snap, err
+		}
+
+		if len(copied) > 0 {
+			snap.Buckets[b] = copied
+		}
+	}
+
+	return snap, nil
+}
+
+// Import merges snap into the store, key by key; imported values replace
+// existing ones with the same key. Only the named buckets are imported,
+// all or nothing. It returns the number of keys written.
+func (s *Store) Import(snap Snapshot, buckets []string) (int, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	count := 0
+	for _, b := range buckets {
+		for k, v := range snap.Buckets[b] {
+			if _, err := tx.Exec(`INSERT OR REPLACE INTO kv (bucket, key, value) VALUES (?, ?, ?)`, b, k, []byte(v)); err != nil {
+				return 0, err
+			}
+			count++
+		}
+	}
+
+	return count, tx.Commit()
+}
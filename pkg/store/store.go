@@ -0,0 +1,192 @@
+// Package store persists brows's local state — history, review markers,
+// watchlists, pins and the API response cache — in one SQLite database,
+// organized as buckets of JSON values addressed by key.
+//
+// All state goes through this package so callers never see SQL. The
+// driver is modernc.org/sqlite, which is pure Go, so release builds stay
+// CGO_ENABLED=0.
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	_ "modernc.org/sqlite"
+)
+
+const defaultPath = ".local/state/brows/state.db"
+
+// legacyFile is the JSON document the store was kept in before SQLite,
+// next to the database.
+const legacyFile = "state.json"
+
+// DefaultPath is where brows keeps its state unless told otherwise.
+func DefaultPath() string {
+	dirname, _ := os.UserHomeDir()
+	return filepath.Join(dirname, defaultPath)
+}
+
+const schema = `CREATE TABLE IF NOT EXISTS kv (
+	bucket TEXT NOT NULL,
+	key    TEXT NOT NULL,
+	value  BLOB NOT NULL,
+	PRIMARY KEY (bucket, key)
+) WITHOUT ROWID`
+
+// Store is safe for concurrent use within a process, and across processes
+// sharing its file: SQLite serializes writers, and each write touches only
+// the key it changes, so one brows doesn't undo another's.
+type Store struct {
+	path string
+	db   *sql.DB
+}
+
+// Open opens the store at path, creating it if needed. An empty path gives
+// a store that lives only in memory.
+func Open(path string) (*Store, error) {
+	dsn := ":memory:"
+	if path != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return nil, err
+		}
+		// wait out other processes' writes instead of failing with SQLITE_BUSY
+		dsn = path + "?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)"
+	}
+
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, err
+	}
+	// an in-memory database lives and dies with its connection
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	s := &Store{path: path, db: db}
+	if path != "" {
+		if err := s.migrateLegacy(filepath.Join(filepath.Dir(path), legacyFile)); err != nil {
+			db.Close()
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// migrateLegacy imports the JSON state file an older brows left at path
+// into an empty store, then removes it.
+func (s *Store) migrateLegacy(path string) error {
+	in, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	var n int
+	if err := s.db.QueryRow(`SELECT count(*) FROM kv`).Scan(&n); err != nil {
+		return err
+	}
+	if n == 0 {
+		var legacy map[string]map[string]json.RawMessage
+		if err := json.Unmarshal(in, &legacy); err != nil {
+			return err
+		}
+
+		buckets := make([]string, 0, len(legacy))
+		for b := range legacy {
+			buckets = append(buckets, b)
+		}
+		if _, err := s.Import(Snapshot{Version: snapshotVersion, Buckets: legacy}, buckets); err != nil {
+			return err
+		}
+	}
+
+	os.Remove(path + ".lock")
+	return os.Remove(path)
+}
+
+// Close releases the database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Path returns the file backing the store, or "" for an in-memory store.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Get decodes the value at bucket/key into v, reporting whether it exists.
+func (s *Store) Get(bucket, key string, v interface{}) (bool, error) {
+	var raw []byte
+	err := s.db.QueryRow(`SELECT value FROM kv WHERE bucket = ? AND key = ?`, bucket, key).Scan(&raw)
+	if err == sql.ErrNoRows {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	return true, json.Unmarshal(raw, v)
+}
+
+// Put stores v at bucket/key.
+func (s *Store) Put(bucket, key string, v interface{}) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(`INSERT OR REPLACE INTO kv (bucket, key, value) VALUES (?, ?, ?)`, bucket, key, raw)
+	return err
+}
+
+// Delete removes bucket/key, if present.
+func (s *Store) Delete(bucket, key string) error {
+	_, err := s.db.Exec(`DELETE FROM kv WHERE bucket = ? AND key = ?`, bucket, key)
+	return err
+}
+
+// Keys returns the keys in bucket starting with prefix, sorted. A store
+// that can't be read has no keys.
+func (s *Store) Keys(bucket, prefix string) []string {
+	keys := []string{}
+
+	rows, err := s.db.Query(`SELECT key FROM kv WHERE bucket = ? AND substr(key, 1, length(?)) = ? ORDER BY key`, bucket, prefix, prefix)
+	if err != nil {
+		return keys
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var k string
+		if rows.Scan(&k) == nil {
+			keys = append(keys, k)
+		}
+	}
+
+	return keys
+}
+
+// Buckets returns the names of all non-empty buckets, sorted.
+func (s *Store) Buckets() []string {
+	buckets := []string{}
+
+	rows, err := s.db.Query(`SELECT DISTINCT bucket FROM kv ORDER BY bucket`)
+	if err != nil {
+		return buckets
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var b string
+		if rows.Scan(&b) == nil {
+			buckets = append(buckets, b)
+		}
+	}
+
+	return buckets
+}
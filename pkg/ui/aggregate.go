@@ -0,0 +1,99 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rubysolo/brows/pkg/releases"
+)
+
+// newerTags returns the tags after the current version, oldest first.
+func (m Model) newerTags() []string {
+	tags := []string{}
+	for _, v := range m.tagList {
+		if v.GreaterThan(m.version) {
+			tags = append(tags, v.Original())
+		}
+	}
+	return tags
+}
+
+// aggregateMarkdown combines the notes of every release after the
+// current version into one document, newest first: everything an upgrade
+// to the latest release brings. Notes evicted from the cache are taken
+// from extra, or else listed in missing. ok is false when there's nothing
+// newer.
+func (m Model) aggregateMarkdown(extra map[string]string) (md string, missing []string, ok bool) {
+	tags := m.newerTags()
+	bodies, missing := m.cachedBodies(tags, extra)
+
+	list := []releases.Release{}
+	for _, tag := range tags {
+		r := m.releases[tag]
+		body, found := bodies[tag]
+		r.Description = body
+		if !found {
+			r.Description = "_Notes not loaded; open the release to fetch them._"
+		}
+		list = append(list, r)
+	}
+
+	if len(list) == 0 {
+		return "", nil, false
+	}
+
+	return releases.AggregateTitled(list), missing, true
+}
+
+// openAggregate shows aggregateMarkdown in place of the focused release,
+// and fetches the notes it's missing to show it again with them.
+func (m *Model) openAggregate(extra map[string]string) tea.Cmd {
+	md, missing, ok := m.aggregateMarkdown(extra)
+	if !ok {
+		m.status = "no releases after " + m.version.Original()
+		return nil
+	}
+
+	offset := m.viewport.YOffset
+	out := m.render(releases.MarkImages(releases.MarkBreaking(md)))
+	m.aggregate = true
+	m.setContent(out)
+	m.viewport.GotoTop()
+	if extra != nil {
+		// the notes fetched again came in while it was being read
+		m.viewport.SetYOffset(offset)
+	}
+	m.status = "all changes since " + m.version.Original()
+
+	if len(missing) > 0 && extra == nil {
+		return m.refetchBodies(refetchAggregate, missing)
+	}
+	return nil
+}
+
+func (m *Model) closeAggregate() {
+	m.aggregate = false
+	m.shownTag = ""
+	m.showFocused()
+}
+
+func (m Model) updateAggregate(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, tea.Quit
+
+	case "a", "esc":
+		m.closeAggregate()
+		return m, nil
+
+	case "y", "Y":
+		cmd := m.copyAggregate(nil)
+		return m, cmd
+	}
+
+	if m.scrollKey(msg) {
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
@@ -0,0 +1,245 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"runtime"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/progress"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rubysolo/brows/pkg/releases"
+)
+
+// assetsPanel lists the files attached to the focused release in place
+// of its notes.
+type assetsPanel struct {
+	open   bool
+	cursor int
+	// platform marks the assets built for this machine; ambiguous is set
+	// when more than one of them is an equally good pick.
+	platform  map[string]bool
+	ambiguous bool
+}
+
+// openAssets lists the focused release's assets, with the cursor on the
+// one built for this machine.
+func (m Model) openAssets() assetsPanel {
+	release, _ := m.focusedRelease()
+	panel := assetsPanel{open: true, platform: map[string]bool{}}
+
+	matches, ok := releases.MatchPlatform(release.Assets, runtime.GOOS, runtime.GOARCH)
+	for _, a := range matches {
+		panel.platform[a.Name] = true
+	}
+	panel.ambiguous = len(matches) > 0 && !ok
+
+	if len(matches) > 0 {
+		for i, a := range release.Assets {
+			if a.Name == matches[0].Name {
+				panel.cursor = i
+			}
+		}
+	}
+
+	return panel
+}
+
+func (m Model) focusedRelease() (releases.Release, bool) {
+	if m.focus < 0 {
+		return releases.Release{}, false
+	}
+
+	r, ok := m.releases[m.tagList[m.focus].Original()]
+	return r, ok
+}
+
+func (m Model) updateAssets(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	release, _ := m.focusedRelease()
+
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, tea.Quit
+
+	case "A", "esc":
+		m.assets.open = false
+
+	case "up", "k":
+		if m.assets.cursor > 0 {
+			m.assets.cursor--
+		}
+
+	case "down", "j":
+		if m.assets.cursor < len(release.Assets)-1 {
+			m.assets.cursor++
+		}
+
+	case "o", "enter":
+		// open the asset's download URL
+		if m.assets.cursor < len(release.Assets) {
+			return m, openURL(release.Assets[m.assets.cursor].URL)
+		}
+
+	case "d":
+		// download and verify the asset
+		if m.transfer.asset != "" {
+			m.status = "already downloading " + m.transfer.asset
+			return m, nil
+		}
+		if m.assets.cursor < len(release.Assets) {
+			asset := release.Assets[m.assets.cursor]
+			m.status = ""
+			m.transfer = transfer{asset: asset.Name, total: int64(asset.Size)}
+			return m, m.download(release, asset)
+		}
+
+	case "O":
+		// open the release page the asset is listed on
+		if release.URL != "" {
+			return m, openURL(release.URL)
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) assetsView() string {
+	release, _ := m.focusedRelease()
+
+	if len(release.Assets) == 0 {
+		return screenCentered(m.viewport.Width, m.viewport.Height).Render("This release has no assets.")
+	}
+
+	width := 0
+	for _, a := range release.Assets {
+		width = max(width, len(a.Name))
+	}
+
+	lines := []string{""}
+	for i, a := range release.Assets {
+		label := fmt.Sprintf("%-*s", width, a.Name)
+		size := "—"
+		if a.Size > 0 {
+			size = formatSize(int64(a.Size))
+		}
+		extra := "  " + releaseStyle.Render(fmt.Sprintf("%10s", size))
+		if m.assets.platform[a.Name] {
+			extra += releaseStyle.Render(fmt.Sprintf("  ← %s/%s", runtime.GOOS, runtime.GOARCH))
+		}
+
+		line := "  " + label + extra
+		if i == m.assets.cursor {
+			line = focusStyle.Render("▸ "+label) + extra
+		}
+		lines = append(lines, line)
+	}
+
+	if m.assets.ambiguous {
+		lines = append(lines, "", releaseStyle.Render(fmt.Sprintf("  several assets match %s/%s; pick one", runtime.GOOS, runtime.GOARCH)))
+	}
+
+	lines = append(lines, "", releaseStyle.Render("  d download · o open download · O open release page · esc close"))
+
+	for len(lines) < m.viewport.Height {
+		lines = append(lines, "")
+	}
+
+	return strings.Join(lines[:max(m.viewport.Height, 0)], "\n")
+}
+
+type downloadDone struct {
+	repo   string
+	asset  string
+	path   string
+	checks []releases.Verification
+	err    error
+}
+
+// downloadProgress reports how much of an asset has arrived.
+type downloadProgress struct {
+	ch          chan tea.Msg
+	repo        string
+	asset       string
+	done, total int64
+}
+
+// transfer is the download in progress, shown with a progress bar.
+type transfer struct {
+	asset       string
+	done, total int64
+}
+
+// download saves asset to the download directory. It's checked against
+// the release's checksums and signatures before it lands there.
+func (m Model) download(r releases.Release, asset releases.Asset) tea.Cmd {
+	downloader, dir := m.downloader, m.downloadDir
+	owner, repo := m.owner, m.repo
+	key := m.repoKey()
+
+	// progress streams through a channel like the release list does; the
+	// last message is the result
+	ch := make(chan tea.Msg, 1)
+	go func() {
+		path, checks, err := downloader.DownloadWithProgress(context.Background(), owner, repo, r, asset, dir, func(done, total int64) {
+			select {
+			case ch <- downloadProgress{ch: ch, repo: key, asset: asset.Name, done: done, total: total}:
+			default:
+			}
+		})
+		ch <- downloadDone{repo: key, asset: asset.Name, path: path, checks: checks, err: err}
+	}()
+
+	return waitForLoad(ch)
+}
+
+// transferView draws the download in progress as a bar with its size.
+func (m Model) transferView() string {
+	t := m.transfer
+	if t.total <= 0 {
+		return fmt.Sprintf("downloading %s… %s", t.asset, formatSize(t.done))
+	}
+
+	bar := progress.New(progress.WithDefaultGradient(), progress.WithWidth(min(30, m.viewport.Width/3)))
+	return fmt.Sprintf("%s %s %s of %s", t.asset, bar.ViewAs(float64(t.done)/float64(t.total)), formatSize(t.done), formatSize(t.total))
+}
+
+// formatSize prints a byte count the way ls -h does.
+func formatSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// downloadStatus summarizes a finished download for the footer.
+func downloadStatus(msg downloadDone) string {
+	var verr *releases.VerificationError
+	if errors.As(msg.err, &verr) {
+		if verr.Check.Err == nil {
+			return verr.Error() + " (--allow-unverified saves it anyway)"
+		}
+		return verr.Error()
+	}
+	if errors.Is(msg.err, fs.ErrExist) {
+		return msg.err.Error() + " (--force replaces it)"
+	}
+	if msg.err != nil {
+		return "download failed: " + msg.err.Error()
+	}
+
+	parts := []string{"saved " + msg.path}
+	for _, c := range msg.checks {
+		parts = append(parts, c.String())
+	}
+
+	return strings.Join(parts, " · ")
+}
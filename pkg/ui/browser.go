@@ -0,0 +1,34 @@
+package ui
+
+import (
+	"os/exec"
+	"runtime"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// openURL opens url in the user's browser without waiting for it.
+func openURL(url string) tea.Cmd {
+	return func() tea.Msg {
+		var cmd *exec.Cmd
+
+		switch runtime.GOOS {
+		case "darwin":
+			cmd = exec.Command("open", url)
+		case "windows":
+			cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+		default:
+			cmd = exec.Command("xdg-open", url)
+		}
+
+		if err := cmd.Start(); err != nil {
+			return statusMsg("could not open browser: " + err.Error())
+		}
+		go cmd.Wait()
+
+		return statusMsg("opened " + url)
+	}
+}
+
+// statusMsg is a transient note shown in the footer.
+type statusMsg string
@@ -0,0 +1,175 @@
+package ui
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"log/slog"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rubysolo/brows/pkg/releases"
+)
+
+const (
+	// defaultRawCacheBytes bounds the markdown bodies kept in memory.
+	defaultRawCacheBytes = 8 << 20
+	// defaultRenderedCacheBytes bounds the glamour output kept in memory;
+	// rendered bodies are several times larger than their source.
+	defaultRenderedCacheBytes = 16 << 20
+)
+
+// bodyCache is a least-recently-used cache of release bodies, bounded by
+// the total size of the bodies it holds. It's shared by pointer between
+// copies of the Model.
+type bodyCache struct {
+	capacity int
+	size     int
+	ll       *list.List
+	items    map[string]*list.Element
+}
+
+type cacheEntry struct {
+	key   string
+	value string
+}
+
+func newBodyCache(capacity int) *bodyCache {
+	return &bodyCache{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+func (c *bodyCache) Get(key string) (string, bool) {
+	if el, ok := c.items[key]; ok {
+		c.ll.MoveToFront(el)
+		return el.Value.(*cacheEntry).value, true
+	}
+	return "", false
+}
+
+func (c *bodyCache) Put(key, value string) {
+	if el, ok := c.items[key]; ok {
+		entry := el.Value.(*cacheEntry)
+		c.size += len(value) - len(entry.value)
+		entry.value = value
+		c.ll.MoveToFront(el)
+	} else {
+		c.items[key] = c.ll.PushFront(&cacheEntry{key, value})
+		c.size += len(value)
+	}
+
+	// always keep the newest entry, even if it alone is over capacity
+	for c.size > c.capacity && c.ll.Len() > 1 {
+		oldest := c.ll.Back()
+		entry := oldest.Value.(*cacheEntry)
+		c.ll.Remove(oldest)
+		delete(c.items, entry.key)
+		c.size -= len(entry.value)
+	}
+}
+
+func (c *bodyCache) Delete(key string) {
+	if el, ok := c.items[key]; ok {
+		c.ll.Remove(el)
+		delete(c.items, key)
+		c.size -= len(el.Value.(*cacheEntry).value)
+	}
+}
+
+// What asked for evicted bodies to be fetched again, and is redone with
+// them once they're back.
+const (
+	refetchPeople = iota
+	refetchSearch
+	refetchAggregate
+	refetchCopy
+	refetchDeprecations
+	refetchFilter
+)
+
+// bodiesFetched carries the notes of releases evicted from the raw cache,
+// fetched again for a view over many releases.
+type bodiesFetched struct {
+	load    string
+	purpose int
+	bodies  map[string]string
+}
+
+// cachedBodies looks up the notes of tags in the raw cache, or else in
+// extra, and lists the tags found in neither.
+func (m Model) cachedBodies(tags []string, extra map[string]string) (map[string]string, []string) {
+	bodies := make(map[string]string, len(tags))
+	missing := []string{}
+
+	for _, tag := range tags {
+		if body, ok := m.raw.Get(tag); ok {
+			bodies[tag] = body
+		} else if body, ok := extra[tag]; ok {
+			bodies[tag] = body
+		} else {
+			missing = append(missing, tag)
+		}
+	}
+
+	return bodies, missing
+}
+
+// refetchBodies fetches the notes of tags again, for purpose to be redone
+// with them. They're handed over whole rather than only through the
+// cache, which may not have room for them all.
+//
+// Listing the releases again costs a request per page of a hundred, so
+// once more tags are missing than there are pages, that's what's done.
+// Otherwise each tag is fetched on its own, as background work paced by
+// the provider's budget.
+func (m *Model) refetchBodies(purpose int, tags []string) tea.Cmd {
+	getter, ok := m.provider.(releases.Getter)
+	if !ok || len(tags) == 0 {
+		return nil
+	}
+
+	m.status = fmt.Sprintf("fetching the notes of %d releases again…", len(tags))
+
+	provider, owner, repo, load := m.provider, m.owner, m.repo, m.load
+	relist := len(tags) > len(m.releases)/100+1
+	return func() tea.Msg {
+		ctx := context.Background()
+		bodies := make(map[string]string, len(tags))
+
+		if relist {
+			list, err := provider.ListReleases(ctx, owner, repo)
+			if err == nil {
+				wanted := make(map[string]bool, len(tags))
+				for _, tag := range tags {
+					wanted[tag] = true
+				}
+				for _, r := range list {
+					if wanted[r.Tag] {
+						bodies[r.Tag] = r.Description
+					}
+				}
+				return bodiesFetched{load: load, purpose: purpose, bodies: bodies}
+			}
+			slog.Error("listing releases", "err", err)
+		}
+
+		var budget *releases.Budget
+		if b, ok := provider.(releases.Budgeted); ok {
+			budget = b.Budget()
+		}
+		for _, tag := range tags {
+			if budget != nil && budget.Background(ctx) != nil {
+				break
+			}
+			r, err := getter.GetRelease(ctx, owner, repo, tag)
+			if err != nil {
+				slog.Error("fetching release", "tag", tag, "err", err)
+				continue
+			}
+			bodies[tag] = r.Description
+		}
+		return bodiesFetched{load: load, purpose: purpose, bodies: bodies}
+	}
+}
@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/muesli/termenv"
+)
+
+// copyText puts text on the system clipboard, naming what was copied in
+// the footer. Where there's no clipboard tool to use, as over SSH, it
+// falls back to an OSC 52 sequence, which most terminals honor by setting
+// their own clipboard.
+func copyText(what, text string) tea.Cmd {
+	return func() tea.Msg {
+		if err := clipboard.WriteAll(text); err != nil {
+			termenv.Copy(text)
+			return statusMsg(fmt.Sprintf("copied %s (via terminal)", what))
+		}
+		return statusMsg("copied " + what)
+	}
+}
+
+// copyFocused copies the focused release's notes as markdown.
+func (m Model) copyFocused() tea.Cmd {
+	if m.focus < 0 {
+		return nil
+	}
+
+	tag := m.tagList[m.focus].Original()
+	body, ok := m.raw.Get(tag)
+	if !ok {
+		return func() tea.Msg { return statusMsg("notes for " + tag + " aren't loaded yet") }
+	}
+
+	return copyText("notes for "+tag, body)
+}
+
+// copyAggregate copies the notes of every release since the current
+// version as one markdown document, once any evicted from the cache have
+// been fetched again.
+func (m *Model) copyAggregate(extra map[string]string) tea.Cmd {
+	md, missing, ok := m.aggregateMarkdown(extra)
+	if !ok {
+		return func() tea.Msg { return statusMsg("no releases after " + m.version.Original()) }
+	}
+
+	if len(missing) > 0 && extra == nil {
+		if cmd := m.refetchBodies(refetchCopy, missing); cmd != nil {
+			return cmd
+		}
+	}
+	return copyText("all changes since "+m.version.Original(), md)
+}
@@ -0,0 +1,282 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rubysolo/brows/pkg/releases"
+)
+
+// commitsPane shows the commits between the previous tag and the focused
+// one in the viewport, in place of the notes. It's what there is to read
+// for tag-only releases without notes.
+//
+// With diff set it compares the marked release with the focused one
+// instead, and adds who contributed and which files changed.
+type commitsPane struct {
+	open    bool
+	loading bool
+	diff    bool
+	key     string // owner/repo:base...head
+	url     string
+}
+
+type comparisonLoaded struct {
+	key        string
+	comparison releases.Comparison
+	err        error
+}
+
+// previousTag is the tag before the focused one on the timeline.
+func (m Model) previousTag() (string, bool) {
+	if m.focus <= 0 {
+		return "", false
+	}
+	return m.tagList[m.focus-1].Original(), true
+}
+
+// openCommits shows the commits between the previous tag and the focused
+// one, fetching them the first time.
+func (m Model) openCommits() (Model, tea.Cmd) {
+	if m.focus < 0 {
+		return m, nil
+	}
+
+	base, ok := m.previousTag()
+	if !ok {
+		m.status = "no earlier tag to compare with"
+		return m, nil
+	}
+	return m.compare(base, m.tagList[m.focus].Original(), false)
+}
+
+// toggleMark marks the focused release for d to compare against, or
+// clears the mark if it's already there.
+func (m *Model) toggleMark() {
+	if m.focus < 0 {
+		return
+	}
+
+	tag := m.tagList[m.focus].Original()
+	if m.mark == tag {
+		m.mark = ""
+		m.status = "cleared the mark"
+		return
+	}
+	m.mark = tag
+	m.status = fmt.Sprintf("marked %s; move to another release and press d to compare", tag)
+}
+
+// openDiff compares the marked release with the focused one, oldest
+// first whichever way round they were picked.
+func (m Model) openDiff() (Model, tea.Cmd) {
+	if m.focus < 0 {
+		return m, nil
+	}
+	if m.mark == "" {
+		m.status = "mark a release with m first"
+		return m, nil
+	}
+
+	marked, ok := m.tagIndex[m.mark]
+	if !ok {
+		m.status = m.mark + " is hidden; show it again to compare with it"
+		return m, nil
+	}
+	if marked == m.focus {
+		m.status = "move to another release to compare with " + m.mark
+		return m, nil
+	}
+
+	base, head := m.mark, m.tagList[m.focus].Original()
+	if marked > m.focus {
+		base, head = head, base
+	}
+	return m.compare(base, head, true)
+}
+
+// compare shows the comparison from base to head, fetching it the first
+// time.
+func (m Model) compare(base, head string, diff bool) (Model, tea.Cmd) {
+	comparer, ok := m.provider.(releases.Comparer)
+	if !ok {
+		m.status = "commit logs aren't available from this forge"
+		return m, nil
+	}
+
+	key := m.repoKey() + ":" + base + "..." + head
+	m.commits = commitsPane{open: true, diff: diff, key: key}
+
+	if cmp, ok := m.enriched.comparisons[key]; ok {
+		m.showCommits(cmp)
+		return m, nil
+	}
+
+	m.commits.loading = true
+	owner, repo := m.owner, m.repo
+	return m, func() tea.Msg {
+		cmp, err := comparer.Compare(context.Background(), owner, repo, base, head)
+		return comparisonLoaded{key: key, comparison: cmp, err: err}
+	}
+}
+
+// emptyNotes stands in for a release published without notes.
+func (m Model) emptyNotes() string {
+	if prev, ok := m.previousTag(); ok {
+		if _, ok := m.provider.(releases.Comparer); ok {
+			return fmt.Sprintf("_No release notes._ Press `c` for the commits since %s.", prev)
+		}
+	}
+	return "_No release notes._"
+}
+
+func (m *Model) closeCommits() {
+	m.commits = commitsPane{}
+	m.shownTag = ""
+	m.showFocused()
+}
+
+func (m *Model) showCommits(cmp releases.Comparison) {
+	m.commits.url = cmp.URL
+
+	md := commitsMarkdown(cmp, m.webURL)
+	if m.commits.diff {
+		md = diffMarkdown(cmp, m.webURL)
+	}
+	m.setContent(m.render(md))
+	m.viewport.GotoTop()
+}
+
+// commitsMarkdown lists a comparison's commits, newest first, as a
+// markdown list.
+func commitsMarkdown(cmp releases.Comparison, webURL string) string {
+	if len(cmp.Commits) == 0 {
+		return fmt.Sprintf("No commits between %s and %s.", cmp.Base, cmp.Head)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %d commits from %s to %s\n\n", cmp.TotalCommits, cmp.Base, cmp.Head)
+	if cmp.TotalCommits > len(cmp.Commits) {
+		fmt.Fprintf(&b, "_Showing the first %d; the rest are on the [comparison page](%s)._\n\n", len(cmp.Commits), cmp.URL)
+	}
+
+	writeCommits(&b, cmp, webURL)
+	return b.String()
+}
+
+// diffMarkdown summarizes everything that changed between two releases:
+// who contributed, which files changed, and the commits themselves.
+func diffMarkdown(cmp releases.Comparison, webURL string) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s → %s\n\n", cmp.Base, cmp.Head)
+
+	authors := contributors(cmp.Commits)
+	fmt.Fprintf(&b, "**%d** commits by **%d** contributors; **%d** files changed, **+%d −%d**. See the [comparison page](%s).\n\n",
+		cmp.TotalCommits, len(authors), len(cmp.Files), cmp.Additions(), cmp.Deletions(), cmp.URL)
+
+	if len(authors) > 0 {
+		b.WriteString("## Contributors\n\n")
+		for _, a := range authors {
+			fmt.Fprintf(&b, "- %s — %d\n", authorLink(a.Commit, webURL), a.commits)
+		}
+		b.WriteString("\n")
+	}
+
+	if len(cmp.Files) > 0 {
+		b.WriteString("## Files changed\n\n| File | Status | + | − |\n| --- | --- | --: | --: |\n")
+		for _, f := range cmp.Files {
+			fmt.Fprintf(&b, "| `%s` | %s | %d | %d |\n", f.Path, f.Status, f.Additions, f.Deletions)
+		}
+		b.WriteString("\n")
+	}
+
+	if len(cmp.Commits) > 0 {
+		b.WriteString("## Commits\n\n")
+		if cmp.TotalCommits > len(cmp.Commits) {
+			fmt.Fprintf(&b, "_Showing the first %d of %d._\n\n", len(cmp.Commits), cmp.TotalCommits)
+		}
+		writeCommits(&b, cmp, webURL)
+	}
+
+	return b.String()
+}
+
+// writeCommits lists cmp's commits, newest first.
+func writeCommits(b *strings.Builder, cmp releases.Comparison, webURL string) {
+	for i := len(cmp.Commits) - 1; i >= 0; i-- {
+		c := cmp.Commits[i]
+		fmt.Fprintf(b, "- [`%s`](%s) %s — %s\n", shortSHA(c.SHA), c.URL, c.Subject, authorLink(c, webURL))
+	}
+}
+
+// authorLink links to a commit author's profile, when the forge matched
+// them to an account.
+func authorLink(c releases.Commit, webURL string) string {
+	if c.Login == "" {
+		return c.Author
+	}
+	return fmt.Sprintf("[@%s](%s%s)", c.Login, webURL, c.Login)
+}
+
+type contributor struct {
+	releases.Commit // the first commit seen, for the name and login
+	commits         int
+}
+
+// contributors counts commits per author, most commits first.
+func contributors(commits []releases.Commit) []contributor {
+	index := map[string]int{}
+	out := []contributor{}
+
+	for _, c := range commits {
+		who := c.Login
+		if who == "" {
+			who = c.Author
+		}
+		if i, ok := index[who]; ok {
+			out[i].commits++
+			continue
+		}
+		index[who] = len(out)
+		out = append(out, contributor{c, 1})
+	}
+
+	sort.SliceStable(out, func(i, j int) bool { return out[i].commits > out[j].commits })
+	return out
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+func (m Model) updateCommits(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, tea.Quit
+
+	case "c", "d", "esc":
+		m.closeCommits()
+		return m, nil
+
+	case "o":
+		if m.commits.url != "" {
+			return m, openURL(m.commits.url)
+		}
+		return m, nil
+	}
+
+	if m.scrollKey(msg) {
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
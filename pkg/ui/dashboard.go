@@ -0,0 +1,190 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rubysolo/brows/pkg/releases"
+)
+
+// DashboardRow is one dependency on the dashboard: where it's developed
+// and how far behind its latest release it is.
+type DashboardRow struct {
+	Name   string
+	Repo   string // owner/repo
+	Source string // the manifest it was read from
+	releases.Behind
+	Error string
+}
+
+// Dashboard lists a project's dependencies with their current and latest
+// versions, and opens the release viewer for the selected one, starting
+// from its current version. esc in the viewer comes back to the list.
+type Dashboard struct {
+	rows   []DashboardRow
+	cursor int
+	offset int
+	status string
+
+	open   func(DashboardRow) (Model, error)
+	viewer *Model
+
+	size tea.WindowSizeMsg
+}
+
+// NewDashboard lists rows; open builds the viewer for a row.
+func NewDashboard(rows []DashboardRow, open func(DashboardRow) (Model, error)) Dashboard {
+	return Dashboard{rows: rows, open: open}
+}
+
+func (d Dashboard) Init() tea.Cmd {
+	return nil
+}
+
+// Err returns the error that stopped the viewer, if any.
+func (d Dashboard) Err() error {
+	if d.viewer != nil {
+		return d.viewer.Err()
+	}
+	return nil
+}
+
+// listHeight is how many rows fit between the heading and the key help.
+func (d Dashboard) listHeight() int {
+	return max(1, d.size.Height-5)
+}
+
+func (d Dashboard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if size, ok := msg.(tea.WindowSizeMsg); ok {
+		d.size = size
+	}
+
+	if d.viewer != nil {
+		if key, ok := msg.(tea.KeyMsg); ok && key.String() == "esc" && !d.viewer.overlayOpen() {
+			d.viewer = nil
+			return d, nil
+		}
+
+		updated, cmd := d.viewer.Update(msg)
+		viewer := updated.(Model)
+		d.viewer = &viewer
+		return d, cmd
+	}
+
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		// the viewer closed with its releases still loading
+		return d, drain(msg)
+	}
+	d.status = ""
+
+	switch key.String() {
+	case "ctrl+c", "q", "esc":
+		return d, tea.Quit
+
+	case "up", "k":
+		if d.cursor > 0 {
+			d.cursor--
+		}
+
+	case "down", "j":
+		if d.cursor < len(d.rows)-1 {
+			d.cursor++
+		}
+
+	case "enter", "right", "l":
+		if len(d.rows) == 0 {
+			break
+		}
+		row := d.rows[d.cursor]
+		if row.Error != "" {
+			d.status = row.Error
+			break
+		}
+
+		m, err := d.open(row)
+		if err != nil {
+			d.status = err.Error()
+			break
+		}
+
+		updated, resize := m.Update(d.size)
+		m = updated.(Model)
+		d.viewer = &m
+		return d, tea.Batch(m.Init(), resize)
+	}
+
+	// keep the cursor in view
+	if d.cursor < d.offset {
+		d.offset = d.cursor
+	} else if d.cursor >= d.offset+d.listHeight() {
+		d.offset = d.cursor - d.listHeight() + 1
+	}
+
+	return d, nil
+}
+
+func (d Dashboard) View() string {
+	if d.viewer != nil {
+		return d.viewer.View()
+	}
+
+	width := d.size.Width
+	title := fmt.Sprintf(" %d dependencies", len(d.rows))
+	title += strings.Repeat(" ", max(0, width-len(title)))
+
+	lines := []string{
+		titleStyle.Render(title),
+		"",
+		releaseStyle.Render(fmt.Sprintf("  %-32s %-14s %-12s %-12s %s", "NAME", "SOURCE", "CURRENT", "LATEST", "BEHIND")),
+	}
+
+	if len(d.rows) == 0 {
+		lines = append(lines, "  No dependencies found.")
+	}
+
+	end := min(len(d.rows), d.offset+d.listHeight())
+	for i := d.offset; i < end; i++ {
+		r := d.rows[i]
+
+		behind := ""
+		switch {
+		case r.Error != "":
+			behind = "error: " + r.Error
+		case r.UpToDate():
+			behind = "up to date"
+		default:
+			behind = fmt.Sprintf("%d releases (%d major, %d minor, %d patch)", r.Releases, r.Major, r.Minor, r.Patch)
+		}
+
+		line := fmt.Sprintf("%-32s %-14s %-12s %-12s %s", r.Name, r.Source, r.Current, r.Latest, behind)
+		if width > 2 && len(line) > width-2 {
+			line = line[:width-2]
+		}
+
+		switch {
+		case i == d.cursor:
+			line = focusStyle.Render("▸ " + line)
+		case r.Error != "" || r.UpToDate():
+			line = releaseStyle.Render("  " + line)
+		case r.Major > 0:
+			line = breakingStyle.Render("  " + line)
+		default:
+			line = "  " + line
+		}
+		lines = append(lines, line)
+	}
+
+	for len(lines) < d.size.Height-1 {
+		lines = append(lines, "")
+	}
+
+	help := "  ↑/↓ select · enter browse releases since the current version · esc back · q quit"
+	if d.status != "" {
+		help = "  " + d.status
+	}
+	lines = append(lines, releaseStyle.Render(help))
+
+	return strings.Join(lines, "\n")
+}
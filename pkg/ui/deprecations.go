@@ -0,0 +1,123 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rubysolo/brows/pkg/releases"
+)
+
+// deprecationsPane lists the deprecation and end-of-life notices across
+// the browsed range, with the release each came from.
+type deprecationsPane struct {
+	open    bool
+	cursor  int
+	notices []deprecation
+}
+
+type deprecation struct {
+	tag  string
+	text string
+}
+
+// openDeprecations lists the notices across the browsed range, and
+// fetches the notes evicted from the cache to list theirs too. extra are
+// notes fetched again, when it's refreshed with them.
+func (m *Model) openDeprecations(extra map[string]string) tea.Cmd {
+	tags := m.browsedRange()
+	bodies, missing := m.cachedBodies(tags, extra)
+
+	cursor := 0
+	if extra != nil {
+		cursor = m.deprecations.cursor
+	}
+
+	notices := collectDeprecations(tags, bodies)
+	m.deprecations = deprecationsPane{open: true, notices: notices, cursor: min(cursor, max(0, len(notices)-1))}
+
+	if len(missing) > 0 && extra == nil {
+		return m.refetchBodies(refetchDeprecations, missing)
+	}
+	return nil
+}
+
+// collectDeprecations gathers the notices in the notes of tags, newest
+// first.
+func collectDeprecations(tags []string, bodies map[string]string) []deprecation {
+	notices := []deprecation{}
+	for i := len(tags) - 1; i >= 0; i-- {
+		for _, text := range releases.Deprecations(bodies[tags[i]]) {
+			notices = append(notices, deprecation{tag: tags[i], text: text})
+		}
+	}
+	return notices
+}
+
+func (m Model) updateDeprecations(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, tea.Quit
+
+	case "E", "esc":
+		m.deprecations.open = false
+
+	case "up", "k":
+		if m.deprecations.cursor > 0 {
+			m.deprecations.cursor--
+		}
+
+	case "down", "j":
+		if m.deprecations.cursor < len(m.deprecations.notices)-1 {
+			m.deprecations.cursor++
+		}
+
+	case "enter":
+		// read the notice in its release
+		if m.deprecations.cursor < len(m.deprecations.notices) {
+			m.deprecations.open = false
+			return m, m.focusRelease(m.tagIndex[m.deprecations.notices[m.deprecations.cursor].tag])
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) deprecationsView() string {
+	if len(m.deprecations.notices) == 0 {
+		return screenCentered(m.viewport.Width, m.viewport.Height).Render("Nothing was deprecated in this range.")
+	}
+
+	tags := m.browsedRange()
+	title := fmt.Sprintf("  %d deprecation notices from %s to %s", len(m.deprecations.notices), tags[0], tags[len(tags)-1])
+	lines := []string{"", title, ""}
+
+	width := 0
+	for _, d := range m.deprecations.notices {
+		width = max(width, len(d.tag))
+	}
+
+	// keep the cursor in view
+	height := max(1, m.viewport.Height-5)
+	first := clamp(m.deprecations.cursor-height/2, 0, max(0, len(m.deprecations.notices)-height))
+
+	for i := first; i < min(len(m.deprecations.notices), first+height); i++ {
+		d := m.deprecations.notices[i]
+		line := truncate(fmt.Sprintf("%-*s  %s", width, d.tag, d.text), m.viewport.Width-2)
+
+		if i == m.deprecations.cursor {
+			line = focusStyle.Render("▸ " + line)
+		} else {
+			line = "  " + line
+		}
+		lines = append(lines, line)
+	}
+
+	lines = append(lines, "", releaseStyle.Render("  enter read it in its release · esc close"))
+
+	for len(lines) < m.viewport.Height {
+		lines = append(lines, "")
+	}
+
+	return strings.Join(lines[:max(m.viewport.Height, 0)], "\n")
+}
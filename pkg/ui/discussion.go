@@ -0,0 +1,109 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rubysolo/brows/pkg/releases"
+)
+
+// discussionPane shows the comments on the focused release's discussion
+// thread in the viewport, in place of its notes.
+type discussionPane struct {
+	open    bool
+	url     string
+	loading bool
+}
+
+type commentsLoaded struct {
+	url      string
+	comments []releases.Comment
+	err      error
+}
+
+// openDiscussion shows the focused release's discussion comments,
+// fetching them the first time.
+func (m Model) openDiscussion() (Model, tea.Cmd) {
+	r, ok := m.focusedRelease()
+	if !ok || r.DiscussionURL == "" {
+		m.status = "this release has no discussion"
+		return m, nil
+	}
+
+	getter, ok := m.provider.(releases.DiscussionGetter)
+	if !ok {
+		return m, openURL(r.DiscussionURL)
+	}
+
+	m.discussion = discussionPane{open: true, url: r.DiscussionURL}
+
+	if comments, ok := m.enriched.comments[r.DiscussionURL]; ok {
+		m.showComments(comments)
+		return m, nil
+	}
+
+	m.discussion.loading = true
+	url := r.DiscussionURL
+	return m, func() tea.Msg {
+		comments, err := getter.GetDiscussionComments(context.Background(), url)
+		return commentsLoaded{url: url, comments: comments, err: err}
+	}
+}
+
+func (m *Model) closeDiscussion() {
+	m.discussion = discussionPane{}
+	m.shownTag = ""
+	m.showFocused()
+}
+
+func (m *Model) showComments(comments []releases.Comment) {
+	out := m.render(commentsMarkdown(m.tagList[m.focus].Original(), comments))
+	m.setContent(out)
+	m.viewport.GotoTop()
+}
+
+func commentsMarkdown(tag string, comments []releases.Comment) string {
+	if len(comments) == 0 {
+		return fmt.Sprintf("No comments on the %s discussion yet.", tag)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %d comments on the %s discussion\n\n", len(comments), tag)
+
+	for _, c := range comments {
+		fmt.Fprintf(&b, "**@%s** · %s", c.Author, c.Created.Format("2006-01-02"))
+		if c.Upvotes > 0 {
+			fmt.Fprintf(&b, " · ▲ %d", c.Upvotes)
+		}
+		if c.Replies > 0 {
+			fmt.Fprintf(&b, " · %d replies", c.Replies)
+		}
+		fmt.Fprintf(&b, "\n\n%s\n\n---\n\n", c.Body)
+	}
+
+	return b.String()
+}
+
+func (m Model) updateDiscussion(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, tea.Quit
+
+	case "C", "esc":
+		m.closeDiscussion()
+		return m, nil
+
+	case "o", "D":
+		return m, openURL(m.discussion.url)
+	}
+
+	if m.scrollKey(msg) {
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
@@ -0,0 +1,148 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rubysolo/brows/pkg/releases"
+)
+
+// enrichment holds API lookups that decorate release bodies before they
+// are rendered. It's shared by pointer between copies of the Model.
+type enrichment struct {
+	milestones map[string]releases.Milestone
+	names      map[string]string
+	comments   map[string][]releases.Comment
+	// issues are keyed by owner/repo#number
+	issues map[string]releases.Issue
+	// comparisons are keyed by owner/repo:base...head
+	comparisons map[string]releases.Comparison
+	pending     map[string]bool
+}
+
+func newEnrichment() *enrichment {
+	return &enrichment{
+		milestones:  make(map[string]releases.Milestone),
+		names:       make(map[string]string),
+		comments:    make(map[string][]releases.Comment),
+		issues:      make(map[string]releases.Issue),
+		comparisons: make(map[string]releases.Comparison),
+		pending:     make(map[string]bool),
+	}
+}
+
+type namesLoaded map[string]string
+
+// fetchNames looks up the display names of logins not seen before.
+func (m Model) fetchNames(logins []string) tea.Cmd {
+	getter, ok := m.provider.(releases.UserGetter)
+	if !ok {
+		return nil
+	}
+
+	missing := []string{}
+	for _, login := range logins {
+		key := "@" + login
+		if _, ok := m.enriched.names[login]; !ok && !m.enriched.pending[key] {
+			m.enriched.pending[key] = true
+			missing = append(missing, login)
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	return func() tea.Msg {
+		found := make(namesLoaded)
+		for _, login := range missing {
+			if u, err := getter.GetUser(context.Background(), login); err == nil {
+				found[login] = u.Name
+			}
+		}
+		return found
+	}
+}
+
+type milestonesLoaded struct {
+	load       string
+	tag        string
+	milestones map[string]releases.Milestone
+}
+
+// enrich folds away the folded sections of body, and decorates the rest
+// with what's already known about the things it links to. It returns a
+// command fetching what isn't known yet.
+func (m Model) enrich(tag, body string) (string, tea.Cmd) {
+	body = releases.MarkImages(releases.MarkBreaking(m.foldSections(tag, body)))
+
+	body, milestones := m.enrichMilestones(tag, body)
+	if !m.expandIssues {
+		return body, milestones
+	}
+
+	body, issues := m.expandIssueRefs(tag, body)
+	return body, tea.Batch(milestones, issues)
+}
+
+// enrichMilestones summarizes the milestones body links to.
+func (m Model) enrichMilestones(tag, body string) (string, tea.Cmd) {
+	refs := releases.MilestoneLinks(body)
+	if len(refs) == 0 {
+		return body, nil
+	}
+
+	missing := []releases.MilestoneRef{}
+	for _, ref := range refs {
+		if _, ok := m.enriched.milestones[ref.URL]; !ok && !m.enriched.pending[ref.URL] {
+			missing = append(missing, ref)
+		}
+	}
+
+	body = annotateMilestones(body, m.enriched.milestones)
+
+	getter, ok := m.provider.(releases.MilestoneGetter)
+	if !ok || len(missing) == 0 {
+		return body, nil
+	}
+
+	for _, ref := range missing {
+		m.enriched.pending[ref.URL] = true
+	}
+
+	load := m.load
+	return body, func() tea.Msg {
+		found := make(map[string]releases.Milestone)
+		for _, ref := range missing {
+			if ms, err := getter.GetMilestone(context.Background(), ref); err == nil {
+				found[ref.URL] = ms
+			}
+		}
+		return milestonesLoaded{load: load, tag: tag, milestones: found}
+	}
+}
+
+// annotateMilestones adds a summary line after each line linking a known
+// milestone.
+func annotateMilestones(body string, known map[string]releases.Milestone) string {
+	lines := strings.Split(body, "\n")
+	out := make([]string, 0, len(lines))
+
+	for _, line := range lines {
+		out = append(out, line)
+
+		for _, ref := range releases.MilestoneLinks(line) {
+			ms, ok := known[ref.URL]
+			if !ok {
+				continue
+			}
+
+			out = append(out, "", fmt.Sprintf("> 🏁 **Milestone %s** (%s): %d/%d issues closed, %d%%",
+				ms.Title, ms.State, ms.Closed, ms.Open+ms.Closed, ms.Percent()), "")
+		}
+	}
+
+	return strings.Join(out, "\n")
+}
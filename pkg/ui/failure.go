@@ -0,0 +1,116 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rubysolo/brows/pkg/releases"
+)
+
+// failureHints say what can be done about each kind of failure.
+var failureHints = map[releases.Failure]string{
+	releases.FailureAuth:      "The token was refused, or can't see this repository.",
+	releases.FailureNotFound:  "Check the repository's name; private ones need a token that can see them.",
+	releases.FailureRateLimit: "The API quota is used up. A token raises it.",
+	releases.FailureNetwork:   "The forge couldn't be reached. Check the connection.",
+}
+
+// tokenScopes are the scopes of the token in use, checked at startup, so a
+// repository that's "not found" can be told apart from one the token
+// can't see.
+type tokenScopes struct {
+	checked bool
+	// classic tokens have scopes; fine-grained ones are granted
+	// repositories one by one
+	classic bool
+	scopes  []string
+}
+
+type scopesChecked tokenScopes
+
+// checkScopes asks the forge what the token can do, if it can tell.
+func (m Model) checkScopes() tea.Cmd {
+	checker, ok := m.provider.(releases.ScopeChecker)
+	if !ok || m.anonymous {
+		return nil
+	}
+	return func() tea.Msg {
+		scopes, classic, err := checker.TokenScopes(context.Background())
+		if err != nil {
+			return nil
+		}
+		return scopesChecked{checked: true, classic: classic, scopes: scopes}
+	}
+}
+
+// scopeHint explains a repository that wasn't found by what the token
+// can see, when the error doesn't already.
+func (m Model) scopeHint() string {
+	var authErr *releases.AuthError
+	if !m.scopes.checked || errors.As(m.failure, &authErr) {
+		return ""
+	}
+
+	if !m.scopes.classic {
+		return "Fine-grained tokens only see the repositories they were granted; check this one is among them."
+	}
+	for _, s := range m.scopes.scopes {
+		if s == "repo" {
+			return ""
+		}
+	}
+	return "Your token has no repo scope, so private repositories look like they don't exist.\nAdd it with: gh auth refresh -s repo"
+}
+
+// failureView explains why the releases couldn't be loaded, in place of
+// the notes, until they're retried.
+func (m Model) failureView() string {
+	kind := releases.Classify(m.failure)
+
+	width := min(m.viewport.Width, 80)
+	lines := []string{warningStyle.Render(" " + kind.String() + " "), ""}
+	lines = append(lines, lipgloss.NewStyle().Width(width).Render(m.failure.Error()))
+
+	if hint, ok := failureHints[kind]; ok {
+		lines = append(lines, "", hint)
+	}
+	if m.anonymous && kind != releases.FailureNetwork {
+		lines = append(lines, "", "brows is running without a GitHub token, so it only sees public repositories,\nat a lower rate limit. Set GITHUB_TOKEN or log in with gh to use one.")
+	}
+	if kind == releases.FailureNotFound {
+		if hint := m.scopeHint(); hint != "" {
+			lines = append(lines, "", hint)
+		}
+	}
+	if kind == releases.FailureRateLimit && m.rateLimit != nil {
+		if _, _, reset, ok := m.rateLimit(); ok {
+			lines = append(lines, fmt.Sprintf("It resets at %s.", reset.Local().Format("15:04")))
+		}
+	}
+	lines = append(lines, "", infoStyle.Render("r retry · ctrl+p another repository · q quit"))
+
+	return screenCentered(m.viewport.Width, m.viewport.Height).Render(strings.Join(lines, "\n"))
+}
+
+func (m Model) updateFailure(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q", "esc":
+		// exit with the error, as if it had never been caught
+		m.err = m.failure
+		return m, tea.Quit
+
+	case "r":
+		m.failure = nil
+		m.loadDone, m.loadTotal = 0, 0
+		m.load = newLoad(m.repoKey())
+		return m, getReleases(m.provider, m.owner, m.repo, m.load)
+
+	case "ctrl+p":
+		return m.openSwitcher()
+	}
+	return m, nil
+}
@@ -0,0 +1,135 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// keywordFilter is the f prompt, and the keyword it leaves set: releases
+// whose notes don't mention it are dimmed, and stepped over.
+type keywordFilter struct {
+	active  bool
+	input   textinput.Model
+	keyword string
+	// matches records, by tag, whether a release mentions the keyword.
+	// It's filled in as releases load, while their bodies are at hand.
+	matches map[string]bool
+	// pending is the keyword waiting for notes evicted from the cache to
+	// be fetched again, before it's applied.
+	pending string
+}
+
+func newFilterInput(keyword string) textinput.Model {
+	input := textinput.New()
+	input.Prompt = "filter "
+	input.Placeholder = "keyword the notes mention, empty to clear"
+	input.SetValue(keyword)
+	input.CursorEnd()
+	return input
+}
+
+func (m Model) openFilter() (Model, tea.Cmd) {
+	if !m.loaded {
+		return m, nil
+	}
+
+	m.filter.active = true
+	m.filter.input = newFilterInput(m.filter.keyword)
+	return m, m.filter.input.Focus()
+}
+
+func (m Model) updateFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+
+	case "esc":
+		m.filter.active = false
+		return m, nil
+
+	case "enter":
+		m.filter.active = false
+		cmd := m.applyFilter(strings.TrimSpace(m.filter.input.Value()), nil)
+		return m, cmd
+	}
+
+	var cmd tea.Cmd
+	m.filter.input, cmd = m.filter.input.Update(msg)
+	return m, cmd
+}
+
+// applyFilter sets the keyword, or clears it when it's empty, and moves
+// the focus to the nearest release that mentions it. Notes evicted from
+// the cache are fetched again first; extra are those notes, once they're
+// back.
+func (m *Model) applyFilter(keyword string, extra map[string]string) tea.Cmd {
+	m.filter.pending = ""
+	if keyword == "" {
+		if m.filter.keyword != "" {
+			m.status = "filter cleared"
+		}
+		m.filter.keyword, m.filter.matches = "", nil
+		return nil
+	}
+
+	// hidden prereleases are matched too, for when they're shown again
+	tags := make([]string, 0, len(m.releases))
+	for tag := range m.releases {
+		tags = append(tags, tag)
+	}
+	bodies, missing := m.cachedBodies(tags, extra)
+	if len(missing) > 0 && extra == nil {
+		if cmd := m.refetchBodies(refetchFilter, missing); cmd != nil {
+			m.filter.pending = keyword
+			return cmd
+		}
+	}
+
+	matches := make(map[string]bool, len(m.releases))
+	for tag, r := range m.releases {
+		r.Description = bodies[tag]
+		matches[tag] = r.Matches(keyword)
+	}
+
+	count := 0
+	for _, v := range m.tagList {
+		if matches[v.Original()] {
+			count++
+		}
+	}
+	if count == 0 {
+		m.status = "no release mentions " + keyword
+		return nil
+	}
+
+	m.filter.keyword, m.filter.matches = keyword, matches
+	m.status = fmt.Sprintf("%d of %d releases mention %s", count, len(m.tagList), keyword)
+
+	if m.focus < 0 || m.filtered(m.focus) {
+		i := m.nextShown(m.focus, 1)
+		if i < 0 {
+			i = m.nextShown(m.focus, -1)
+		}
+		return m.focusRelease(i)
+	}
+	return nil
+}
+
+// filtered reports whether the release at i is dimmed by the filter.
+func (m Model) filtered(i int) bool {
+	return m.filter.keyword != "" && !m.filter.matches[m.tagList[i].Original()]
+}
+
+// nextShown is the index of the first release after (dir 1) or before
+// (dir -1) from that the filter lets through, or -1.
+func (m Model) nextShown(from, dir int) int {
+	for i := from + dir; i >= 0 && i < len(m.tagList); i += dir {
+		if !m.filtered(i) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,241 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rubysolo/brows/pkg/releases"
+)
+
+// noteLinkRe matches the links in markdown notes: "[text](url)", with an
+// optional title, and bare or <bracketed> web URLs. Group 1 is a markdown
+// link's text and group 2 its target; group 3 is a bare URL.
+var noteLinkRe = regexp.MustCompile(`\[([^\]]*)\]\(([^)\s]+)(?:\s+"[^"]*")?\)|<?(https?://[^\s<>()\[\]]+)>?`)
+
+// noteLink is a link in a release's notes.
+type noteLink struct {
+	text string
+	url  string
+}
+
+// linkFocus steps through the links in the shown release's notes with
+// tab, for enter to follow.
+type linkFocus struct {
+	tag   string
+	links []noteLink
+	// current indexes the focused link, or is -1.
+	current int
+	// line is the focused link's line in the rendered notes, or -1 when
+	// it couldn't be placed.
+	line   int
+	needle string
+}
+
+// filePane shows a file from the repository, as of the focused release,
+// in place of the notes: where a relative link in them leads.
+type filePane struct {
+	open    bool
+	loading bool
+	key     string // owner/repo@tag:path
+	path    string
+}
+
+type fileLoaded struct {
+	key     string
+	content string
+	err     error
+}
+
+// noteLinks lists the links in md, in the order they appear, each once.
+// Images aren't links.
+func noteLinks(md string) []noteLink {
+	links := []noteLink{}
+	seen := map[string]bool{}
+
+	for _, loc := range noteLinkRe.FindAllStringSubmatchIndex(md, -1) {
+		if loc[0] > 0 && md[loc[0]-1] == '!' {
+			continue
+		}
+
+		match := make([]string, len(loc)/2)
+		for g := range match {
+			if loc[2*g] >= 0 {
+				match[g] = md[loc[2*g]:loc[2*g+1]]
+			}
+		}
+
+		l := noteLink{text: match[1], url: match[2]}
+		if match[3] != "" {
+			l = noteLink{url: strings.TrimRight(match[3], ".,;:!?'\"")}
+		}
+		if l.url == "" || strings.HasPrefix(l.url, "#") || seen[l.url] {
+			continue
+		}
+		seen[l.url] = true
+		links = append(links, l)
+	}
+
+	return links
+}
+
+// repoPath is where a relative link in the notes points inside the
+// repository, or false for links elsewhere.
+func repoPath(link string) (string, bool) {
+	if strings.Contains(link, "://") || strings.HasPrefix(link, "mailto:") || strings.HasPrefix(link, "//") {
+		return "", false
+	}
+
+	link, _, _ = strings.Cut(link, "#")
+	link, _, _ = strings.Cut(link, "?")
+	p := path.Clean(strings.TrimPrefix(link, "/"))
+	if p == "." || p == ".." || strings.HasPrefix(p, "../") {
+		return "", false
+	}
+	return p, true
+}
+
+// cycleLink focuses the next (dir 1) or previous (dir -1) link in the
+// shown release's notes, wrapping around, and scrolls it into view.
+func (m *Model) cycleLink(dir int) {
+	if m.focus < 0 {
+		return
+	}
+
+	tag := m.tagList[m.focus].Original()
+	if m.links.tag != tag {
+		raw, _ := m.raw.Get(tag)
+		m.links = linkFocus{tag: tag, links: noteLinks(raw), current: -1, line: -1}
+	}
+
+	n := len(m.links.links)
+	if n == 0 {
+		m.status = "no links in these notes"
+		return
+	}
+
+	i := m.links.current
+	if i < 0 && dir < 0 {
+		i = 0
+	}
+	i = ((i+dir)%n + n) % n
+	m.links.current = i
+
+	l := m.links.links[i]
+	m.links.line, m.links.needle = -1, ""
+	if out, ok := m.rendered.Get(tag); ok {
+		for _, needle := range []string{l.url, l.text} {
+			if line := renderedLine(out, needle); line >= 0 {
+				m.links.line, m.links.needle = line, needle
+				break
+			}
+		}
+	}
+
+	if line := m.links.line; line >= 0 && (line < m.viewport.YOffset || line >= m.viewport.YOffset+m.viewport.Height) {
+		m.viewport.SetYOffset(line)
+	}
+
+	m.status = fmt.Sprintf("link %d of %d: %s · enter follows", i+1, n, l.url)
+}
+
+// focusedLink is the link tab last focused, if it's in the notes shown.
+func (m Model) focusedLink() (noteLink, bool) {
+	if m.links.current < 0 || m.links.tag != m.shownTag || m.links.tag == "" {
+		return noteLink{}, false
+	}
+	return m.links.links[m.links.current], true
+}
+
+// highlightLink shows the focused link in the visible body in reverse
+// video, the way highlight shows search matches.
+func (m Model) highlightLink(view string) string {
+	if _, ok := m.focusedLink(); !ok || m.links.line < 0 || m.file.open {
+		return view
+	}
+
+	lines := strings.Split(view, "\n")
+	if i := m.links.line - m.viewport.YOffset; i >= 0 && i < len(lines) {
+		lines[i] = highlightLine(lines[i], m.links.needle)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// followLink opens the focused link.
+func (m Model) followLink() (Model, tea.Cmd) {
+	l, ok := m.focusedLink()
+	if !ok {
+		return m, nil
+	}
+	return m.followURL(l.url)
+}
+
+// followURL opens a link from the notes: a file in the repository is
+// fetched and shown in place, anything else goes to the browser.
+func (m Model) followURL(link string) (Model, tea.Cmd) {
+	p, inRepo := repoPath(link)
+	if !inRepo {
+		return m, openURL(link)
+	}
+	if m.focus < 0 {
+		return m, nil
+	}
+
+	getter, ok := m.provider.(releases.FileGetter)
+	if !ok {
+		m.status = "files can't be fetched from this forge"
+		return m, nil
+	}
+
+	tag := m.tagList[m.focus].Original()
+	key := m.repoKey() + "@" + tag + ":" + p
+	m.file = filePane{open: true, loading: true, key: key, path: p}
+
+	owner, repo := m.owner, m.repo
+	return m, func() tea.Msg {
+		content, err := getter.GetFile(context.Background(), owner, repo, tag, p)
+		return fileLoaded{key: key, content: content, err: err}
+	}
+}
+
+// showFile renders a fetched file: markdown as it is, anything else as a
+// code block.
+func (m *Model) showFile(content string) {
+	md := content
+	if ext := strings.ToLower(path.Ext(m.file.path)); ext != ".md" && ext != ".markdown" {
+		md = "```" + strings.TrimPrefix(ext, ".") + "\n" + strings.TrimRight(content, "\n") + "\n```"
+	}
+
+	m.file.loading = false
+	m.setContent(m.render(md))
+	m.viewport.GotoTop()
+	m.status = m.file.path + " · esc back to the notes"
+}
+
+func (m *Model) closeFile() {
+	m.file = filePane{}
+	m.shownTag = ""
+	m.showFocused()
+}
+
+func (m Model) updateFile(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, tea.Quit
+
+	case "esc", "backspace":
+		m.closeFile()
+		return m, nil
+	}
+
+	if m.scrollKey(msg) {
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// helpKeys are the viewer's keys, as the ? overlay lists them.
+var helpKeys = [][2]string{
+	{"←/h →/l", "previous / next release"},
+	{"↑/k ↓/j", "scroll the notes, or move in the list"},
+	{"g", "go to a tag"},
+	{"home/gg end/G", "first release / latest stable"},
+	{"/ n N", "search, next / previous match"},
+	{"f", "only releases mentioning a keyword"},
+	{"a", "every newer release as one document"},
+	{"y Y", "copy these notes / all newer ones"},
+	{"A", "assets"},
+	{"i", "images in the notes"},
+	{"c", "commits since the previous tag"},
+	{"x", "titles of the linked issues and PRs"},
+	{"tab ] [ enter", "next / previous link, follow it"},
+	{"} { z", "next / previous section, fold it"},
+	{"m d", "mark / compare with the mark"},
+	{"C D", "discussion comments / open the thread"},
+	{"P @", "new contributors / mentioned people"},
+	// not D, which opens discussion threads
+	{"E", "deprecations since your version"},
+	{"R", "cycle the review state"},
+	{"p", "hide or show prereleases"},
+	{"v", "release list / timeline"},
+	{"*", "pin the repository"},
+	{"ctrl+p ctrl+t", "switch repository / new tab"},
+	{"q esc", "quit"},
+}
+
+func (m Model) updateHelp(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "?", "esc", "q":
+		m.help = false
+	}
+	return m, nil
+}
+
+// helpView lists the keys, and what the timeline's colors and markers
+// mean.
+func (m Model) helpView() string {
+	keys := []string{"", "  Keys", ""}
+	for _, k := range helpKeys {
+		keys = append(keys, fmt.Sprintf("  %-14s %s", k[0], releaseStyle.Render(k[1])))
+	}
+
+	lines := []string{"", "    Timeline", ""}
+	legend := []struct {
+		style glyphStyle
+		text  string
+	}{
+		{glyphFocus, "focused release"},
+		{glyphLatest, "latest stable release"},
+		{glyphBreaking, "has breaking changes"},
+		{glyphFresh, "published in the last month"},
+		{glyphRecent, "in the last six months"},
+		{glyphRelease, "in the last year, or undated"},
+		{glyphOld, "more than a year ago"},
+	}
+	for _, l := range legend {
+		lines = append(lines, fmt.Sprintf("    %s  %s", glyphStyles[l.style].Render("●"), l.text))
+	}
+	lines = append(lines, "    ⌂  your version", "    ▴  marked for comparison", "    ·  doesn't mention the filter keyword", "    • ✓ ✗  reviewed, approved, skipped")
+
+	// the legend goes beside the keys when there's room, or else below
+	if lipgloss.Width(strings.Join(keys, "\n"))+lipgloss.Width(strings.Join(lines, "\n")) <= m.viewport.Width {
+		lines = strings.Split(lipgloss.JoinHorizontal(lipgloss.Top, strings.Join(keys, "\n"), strings.Join(lines, "\n")), "\n")
+	} else {
+		lines = append(keys, lines...)
+	}
+
+	lines = append(lines, "", releaseStyle.Render("  ? or esc close"))
+
+	for len(lines) < m.viewport.Height {
+		lines = append(lines, "")
+	}
+
+	return strings.Join(lines[:max(m.viewport.Height, 0)], "\n")
+}
@@ -0,0 +1,222 @@
+package ui
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rubysolo/brows/pkg/releases"
+	"golang.org/x/term"
+)
+
+// The graphics protocols images can be previewed with.
+const (
+	imagesKitty  = "kitty"
+	imagesITerm2 = "iterm2"
+	imagesSixel  = "sixel"
+	imagesNone   = "none"
+)
+
+// maxImageBytes bounds how much of an image is downloaded to preview it.
+const maxImageBytes = 20 << 20
+
+// imageProtocol resolves the ImageProtocol option: "" or "auto" guesses
+// from the terminal's environment, which only kitty and iTerm2 (and the
+// terminals that copy them) announce. Sixel has to be asked for. It
+// returns "" when images can't be shown.
+func imageProtocol(option string) string {
+	switch option {
+	case "", "auto":
+	case imagesNone:
+		return ""
+	default:
+		return option
+	}
+
+	switch {
+	case os.Getenv("KITTY_WINDOW_ID") != "" || os.Getenv("TERM") == "xterm-kitty" || os.Getenv("TERM_PROGRAM") == "ghostty":
+		return imagesKitty
+	case os.Getenv("TERM_PROGRAM") == "iTerm.app" || os.Getenv("TERM_PROGRAM") == "WezTerm":
+		return imagesITerm2
+	}
+	return ""
+}
+
+// previewImages shows the images in the focused release's notes full
+// screen, one after another, while the TUI steps aside.
+func (m Model) previewImages() tea.Cmd {
+	if m.focus < 0 {
+		return nil
+	}
+
+	body, _ := m.raw.Get(m.tagList[m.focus].Original())
+	images := []releases.Image{}
+	for _, img := range releases.Images(body) {
+		if strings.HasPrefix(img.URL, "https://") || strings.HasPrefix(img.URL, "http://") {
+			images = append(images, img)
+		}
+	}
+
+	switch {
+	case len(images) == 0:
+		return func() tea.Msg { return statusMsg("no images in these notes") }
+	case m.imageProtocol == "":
+		return func() tea.Msg {
+			return statusMsg("this terminal can't show images; set image_protocol to kitty, iterm2 or sixel")
+		}
+	}
+
+	client := m.downloader.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	preview := &imagePreview{images: images, client: client, protocol: m.imageProtocol, width: m.viewport.Width}
+	return tea.Exec(preview, func(err error) tea.Msg {
+		if err != nil {
+			return statusMsg("could not show images: " + err.Error())
+		}
+		return statusMsg("")
+	})
+}
+
+// imagePreview draws images on the terminal with a graphics protocol. It's
+// a tea.ExecCommand, run with the terminal handed over to it.
+type imagePreview struct {
+	images   []releases.Image
+	client   *http.Client
+	protocol string
+	width    int
+
+	in  io.Reader
+	out io.Writer
+}
+
+func (p *imagePreview) SetStdin(r io.Reader)  { p.in = r }
+func (p *imagePreview) SetStdout(w io.Writer) { p.out = w }
+func (p *imagePreview) SetStderr(io.Writer)   {}
+
+// Run shows each image under its alt text and URL, waiting for a key
+// between them; q or esc goes back early.
+func (p *imagePreview) Run() error {
+	if f, ok := p.in.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		state, err := term.MakeRaw(int(f.Fd()))
+		if err != nil {
+			return err
+		}
+		defer term.Restore(int(f.Fd()), state)
+	}
+
+	key := make([]byte, 1)
+	for i, img := range p.images {
+		alt := img.Alt
+		if alt == "" {
+			alt = "image"
+		}
+		fmt.Fprintf(p.out, "\x1b[2J\x1b[H%s (%d of %d)\r\n%s\r\n\r\n", alt, i+1, len(p.images), img.URL)
+
+		data, err := p.fetch(img.URL)
+		if err == nil {
+			err = p.draw(data)
+		}
+		if err != nil {
+			fmt.Fprintf(p.out, "can't show it: %v\r\n", err)
+		}
+
+		if i < len(p.images)-1 {
+			fmt.Fprint(p.out, "\r\n\r\nany key for the next image, q to go back")
+		} else {
+			fmt.Fprint(p.out, "\r\n\r\nany key to go back")
+		}
+		if _, err := p.in.Read(key); err != nil {
+			break
+		}
+		if key[0] == 'q' || key[0] == 0x1b || key[0] == 0x03 {
+			break
+		}
+	}
+
+	fmt.Fprint(p.out, "\x1b[2J\x1b[H")
+	return nil
+}
+
+func (p *imagePreview) fetch(url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(context.Background(), "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s", resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxImageBytes))
+}
+
+// draw writes data at the cursor, scaled to the terminal's width where the
+// protocol allows it.
+func (p *imagePreview) draw(data []byte) error {
+	switch p.protocol {
+	case imagesKitty:
+		return p.drawKitty(data)
+	case imagesITerm2:
+		fmt.Fprintf(p.out, "\x1b]1337;File=inline=1;size=%d;width=%d;preserveAspectRatio=1:%s\a",
+			len(data), p.width, base64.StdEncoding.EncodeToString(data))
+		return nil
+	case imagesSixel:
+		// encoding sixels is left to libsixel
+		cmd := exec.Command("img2sixel")
+		cmd.Stdin, cmd.Stdout = bytes.NewReader(data), p.out
+		return cmd.Run()
+	}
+	return fmt.Errorf("unknown image protocol %q (expected kitty, iterm2 or sixel)", p.protocol)
+}
+
+// drawKitty sends the image as PNG, converting it if need be, in the
+// chunks kitty's protocol expects.
+func (p *imagePreview) drawKitty(data []byte) error {
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("unsupported image: %v", err)
+	}
+	if format != "png" {
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, img); err != nil {
+			return err
+		}
+		data = buf.Bytes()
+	}
+
+	const chunk = 4096
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for i := 0; i < len(encoded); i += chunk {
+		end := min(i+chunk, len(encoded))
+		more := 0
+		if end < len(encoded) {
+			more = 1
+		}
+
+		if i == 0 {
+			fmt.Fprintf(p.out, "\x1b_Gf=100,a=T,c=%d,m=%d;%s\x1b\\", p.width, more, encoded[i:end])
+		} else {
+			fmt.Fprintf(p.out, "\x1b_Gm=%d;%s\x1b\\", more, encoded[i:end])
+		}
+	}
+	return nil
+}
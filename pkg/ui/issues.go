@@ -0,0 +1,129 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rubysolo/brows/pkg/releases"
+)
+
+// issueConcurrency bounds how many issues are looked up at once; notes
+// generated from pull requests can refer to a hundred of them.
+const issueConcurrency = 4
+
+type issuesLoaded struct {
+	load   string
+	tag    string
+	issues map[string]releases.Issue
+}
+
+// toggleExpandIssues shows or hides the titles and labels of the issues and
+// pull requests the notes refer to, looking them up as they're needed.
+func (m *Model) toggleExpandIssues() tea.Cmd {
+	if _, ok := m.provider.(releases.IssueGetter); !ok {
+		m.status = "this forge's issues can't be looked up"
+		return nil
+	}
+
+	m.expandIssues = !m.expandIssues
+	if m.expandIssues {
+		m.status = "showing issue and pull request titles"
+	} else {
+		m.status = "hiding issue and pull request titles"
+	}
+	return m.rerender()
+}
+
+// expandIssueRefs adds the title and labels of each known issue or pull
+// request to the lines referring to it, and returns a command looking up
+// the ones not known yet.
+func (m Model) expandIssueRefs(tag, body string) (string, tea.Cmd) {
+	getter, ok := m.provider.(releases.IssueGetter)
+	if !ok {
+		return body, nil
+	}
+
+	missing := []releases.IssueRef{}
+	lines := strings.Split(body, "\n")
+
+	for i, line := range lines {
+		parts := []string{}
+		refs := releases.IssueLinks(line, m.owner, m.repo)
+
+		for _, ref := range refs {
+			issue, ok := m.enriched.issues[ref.String()]
+			if !ok {
+				if !m.enriched.pending[ref.String()] {
+					m.enriched.pending[ref.String()] = true
+					missing = append(missing, ref)
+				}
+				continue
+			}
+
+			part := issueSummary(issue, line)
+			if part == "" {
+				continue
+			}
+			if len(refs) > 1 {
+				part = fmt.Sprintf("#%d %s", ref.Number, part)
+			}
+			parts = append(parts, part)
+		}
+
+		if len(parts) > 0 {
+			lines[i] = strings.TrimRight(line, " ") + " — " + strings.Join(parts, "; ")
+		}
+	}
+	body = strings.Join(lines, "\n")
+
+	if len(missing) == 0 {
+		return body, nil
+	}
+
+	load := m.load
+	return body, func() tea.Msg {
+		var (
+			wg sync.WaitGroup
+			mu sync.Mutex
+		)
+		found := make(map[string]releases.Issue)
+		sem := make(chan struct{}, issueConcurrency)
+
+		for _, ref := range missing {
+			wg.Add(1)
+			go func(ref releases.IssueRef) {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+
+				if issue, err := getter.GetIssue(context.Background(), ref); err == nil {
+					mu.Lock()
+					found[ref.String()] = issue
+					mu.Unlock()
+				}
+			}(ref)
+		}
+		wg.Wait()
+
+		return issuesLoaded{load: load, tag: tag, issues: found}
+	}
+}
+
+// issueSummary is the issue's title in italics and its labels as code,
+// leaving out the title when line already says it, as generated notes do.
+func issueSummary(issue releases.Issue, line string) string {
+	parts := []string{}
+	if issue.Title != "" && !strings.Contains(strings.ToLower(line), strings.ToLower(issue.Title)) {
+		parts = append(parts, "*"+issue.Title+"*")
+	}
+	for _, l := range issue.Labels {
+		parts = append(parts, "`"+l+"`")
+	}
+	if issue.State == "open" {
+		parts = append(parts, "(open)")
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,131 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// jumpCompletions is how many matching tags the jump prompt suggests.
+const jumpCompletions = 5
+
+// jumpPrompt is the g prompt for going straight to a tag.
+type jumpPrompt struct {
+	active  bool
+	input   textinput.Model
+	matches []string
+}
+
+func newJumpInput() textinput.Model {
+	input := textinput.New()
+	input.Prompt = "go to "
+	input.Placeholder = "tag"
+	return input
+}
+
+func (m Model) openJump() (Model, tea.Cmd) {
+	if !m.loaded || len(m.tagList) == 0 {
+		return m, nil
+	}
+
+	m.jump = jumpPrompt{active: true, input: newJumpInput()}
+	m.jump.matches = m.matchTags("")
+	return m, m.jump.input.Focus()
+}
+
+// matchTags fuzzy-matches query against the timeline's tags, best first;
+// ties go to the newer release.
+func (m Model) matchTags(query string) []string {
+	type scored struct {
+		tag   string
+		index int
+		score int
+	}
+
+	matches := []scored{}
+	for i, v := range m.tagList {
+		tag := v.Original()
+		if query == "" {
+			matches = append(matches, scored{tag, i, 0})
+			continue
+		}
+		// "2.7.1" should find "v2.7.1"
+		if score, ok := fuzzyScore(query, strings.TrimPrefix(tag, "v")); ok {
+			matches = append(matches, scored{tag, i, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score < matches[j].score
+		}
+		return matches[i].index > matches[j].index
+	})
+
+	tags := make([]string, 0, min(len(matches), jumpCompletions))
+	for _, s := range matches[:min(len(matches), jumpCompletions)] {
+		tags = append(tags, s.tag)
+	}
+	return tags
+}
+
+func (m Model) updateJump(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+
+	case "esc":
+		m.jump.active = false
+		return m, nil
+
+	case "g":
+		// g g goes to the first release, like home
+		if m.jump.input.Value() == "" {
+			m.jump.active = false
+			return m, m.focusRelease(0)
+		}
+
+	case "tab":
+		// complete to the best match
+		if len(m.jump.matches) > 0 {
+			m.jump.input.SetValue(m.jump.matches[0])
+			m.jump.input.CursorEnd()
+		}
+		return m, nil
+
+	case "enter":
+		m.jump.active = false
+		if len(m.jump.matches) == 0 {
+			m.status = "no tag matches " + m.jump.input.Value()
+			return m, nil
+		}
+
+		index, ok := m.tagIndex[m.jump.matches[0]]
+		if !ok || index == m.focus {
+			return m, nil
+		}
+		m.focus = index
+		return m, m.focusChanged()
+	}
+
+	var cmd tea.Cmd
+	m.jump.input, cmd = m.jump.input.Update(msg)
+	m.jump.matches = m.matchTags(m.jump.input.Value())
+	return m, cmd
+}
+
+// jumpView is the prompt followed by its suggestions, best first.
+func (m Model) jumpView() string {
+	suggestions := ""
+	if len(m.jump.matches) > 0 {
+		suggestions = "  " + focusStyle.Render(m.jump.matches[0])
+		if len(m.jump.matches) > 1 {
+			suggestions += " " + releaseStyle.Render(strings.Join(m.jump.matches[1:], " "))
+		}
+	} else if m.jump.input.Value() != "" {
+		suggestions = "  " + releaseStyle.Render("no match")
+	}
+
+	return m.jump.input.View() + suggestions
+}
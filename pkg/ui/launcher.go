@@ -0,0 +1,225 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rubysolo/brows/pkg/releases"
+	"github.com/rubysolo/brows/pkg/store"
+)
+
+// Launcher is what brows opens to without arguments: the pinned, aliased
+// and recently browsed repositories, filtered by what's typed, or any
+// other repository typed out. The chosen one opens in the release viewer
+// from the version it was last browsed at; esc in the viewer comes back
+// here.
+type Launcher struct {
+	store       *store.Store
+	title       string
+	empty       string
+	placeholder string
+	targets     func() []switchTarget
+	switcher    switcher
+	status      string
+
+	open   func(repo, version string) (Model, error)
+	viewer *Model
+
+	size tea.WindowSizeMsg
+}
+
+// NewLauncher offers the repositories in opts' store, pins and aliases;
+// open builds the viewer for the chosen one.
+func NewLauncher(opts Options, open func(repo, version string) (Model, error)) Launcher {
+	if opts.Store == nil {
+		opts.Store, _ = store.Open("")
+	}
+
+	l := Launcher{store: opts.Store, title: " brows", open: open}
+	l.empty = "  Nothing browsed yet; type owner/repo and press enter."
+	l.placeholder = "pinned, aliased or recent repo, or type owner/repo"
+	l.targets = func() []switchTarget { return collectTargets(opts.Store, opts.Pins, opts.Aliases) }
+	l.reset()
+	return l
+}
+
+// NewStarredLauncher offers the user's starred repositories instead, with
+// their latest releases, most recently starred first.
+func NewStarredLauncher(opts Options, starred []releases.Starred, open func(repo, version string) (Model, error)) Launcher {
+	if opts.Store == nil {
+		opts.Store, _ = store.Open("")
+	}
+
+	targets := make([]switchTarget, len(starred))
+	for i, s := range starred {
+		note := "no releases"
+		if s.LatestTag != "" {
+			note = s.LatestTag
+			if !s.Published.IsZero() {
+				note += " · " + s.Published.Local().Format("2006-01-02")
+			}
+		}
+		targets[i] = switchTarget{label: s.Repo, repo: s.Repo, kind: "starred", note: note}
+	}
+
+	l := Launcher{store: opts.Store, title: fmt.Sprintf(" %d starred repositories", len(starred)), open: open}
+	l.empty = "  No starred repositories."
+	l.placeholder = "filter starred repos, or type owner/repo"
+	l.targets = func() []switchTarget { return targets }
+	l.reset()
+	return l
+}
+
+// reset empties the input and reads the targets afresh, since browsing
+// adds to the history.
+func (l *Launcher) reset() {
+	l.switcher = switcher{active: true, input: newSwitcherInput(), targets: l.targets()}
+	l.switcher.input.Placeholder = l.placeholder
+	l.switcher.filter()
+}
+
+func (l Launcher) Init() tea.Cmd {
+	return l.switcher.input.Focus()
+}
+
+// Err returns the error that stopped the viewer, if any.
+func (l Launcher) Err() error {
+	if l.viewer != nil {
+		return l.viewer.Err()
+	}
+	return nil
+}
+
+// listHeight is how many targets fit between the input and the key help.
+func (l Launcher) listHeight() int {
+	return max(1, l.size.Height-6)
+}
+
+func (l Launcher) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if size, ok := msg.(tea.WindowSizeMsg); ok {
+		l.size = size
+	}
+
+	if l.viewer != nil {
+		if key, ok := msg.(tea.KeyMsg); ok && key.String() == "esc" && !l.viewer.overlayOpen() {
+			l.viewer = nil
+			l.reset()
+			return l, l.switcher.input.Focus()
+		}
+
+		updated, cmd := l.viewer.Update(msg)
+		viewer := updated.(Model)
+		l.viewer = &viewer
+		return l, cmd
+	}
+
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		// the viewer closed with its releases still loading
+		return l, drain(msg)
+	}
+	l.status = ""
+
+	switch key.String() {
+	case "ctrl+c", "esc":
+		return l, tea.Quit
+
+	case "up", "ctrl+k":
+		if l.switcher.cursor > 0 {
+			l.switcher.cursor--
+		}
+		return l, nil
+
+	case "down", "ctrl+j":
+		if l.switcher.cursor < len(l.switcher.matches)-1 {
+			l.switcher.cursor++
+		}
+		return l, nil
+
+	case "enter":
+		repo := strings.TrimSpace(l.switcher.input.Value())
+		if l.switcher.cursor < len(l.switcher.matches) {
+			repo = l.switcher.matches[l.switcher.cursor].repo
+		}
+		if repo == "" {
+			return l, nil
+		}
+
+		m, err := l.open(repo, lastVersion(l.store, repo).Original())
+		if err != nil {
+			l.status = err.Error()
+			return l, nil
+		}
+
+		updated, resize := m.Update(l.size)
+		m = updated.(Model)
+		l.viewer = &m
+		return l, tea.Batch(m.Init(), resize)
+	}
+
+	var cmd tea.Cmd
+	l.switcher.input, cmd = l.switcher.input.Update(key)
+	l.switcher.filter()
+	return l, cmd
+}
+
+func (l Launcher) View() string {
+	if l.viewer != nil {
+		return l.viewer.View()
+	}
+
+	width := l.size.Width
+	title := l.title
+	title += strings.Repeat(" ", max(0, width-len(title)))
+
+	lines := []string{titleStyle.Render(title), "", "  " + l.switcher.input.View(), ""}
+
+	if len(l.switcher.targets) == 0 {
+		lines = append(lines, releaseStyle.Render(l.empty))
+	} else if len(l.switcher.matches) == 0 {
+		lines = append(lines, releaseStyle.Render("  no matches; enter opens what's typed"))
+	}
+
+	// keep the cursor in view
+	first := max(0, l.switcher.cursor-l.listHeight()+1)
+	end := min(len(l.switcher.matches), first+l.listHeight())
+	for i := first; i < end; i++ {
+		t := l.switcher.matches[i]
+
+		label := t.label
+		if t.kind == "alias" {
+			label = fmt.Sprintf("%s → %s", t.label, t.repo)
+		}
+
+		marker := " "
+		if t.kind == "pin" {
+			marker = "★"
+		}
+
+		note := t.kind
+		if t.note != "" {
+			note = t.note
+		}
+
+		line := fmt.Sprintf("%s %-48s %s", marker, label, releaseStyle.Render(note))
+		if i == l.switcher.cursor {
+			line = focusStyle.Render("▸ ") + line
+		} else {
+			line = "  " + line
+		}
+		lines = append(lines, line)
+	}
+
+	for len(lines) < l.size.Height-1 {
+		lines = append(lines, "")
+	}
+
+	help := "  ↑/↓ select · enter browse · esc quit"
+	if l.status != "" {
+		help = "  " + l.status
+	}
+	lines = append(lines, releaseStyle.Render(help))
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,125 @@
+package ui
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// A linkRule finds references in rendered output. Group 2 of re is the
+// text that becomes the link; url builds its target from the submatches.
+type linkRule struct {
+	re  *regexp.Regexp
+	url func(m Model, match []string) string
+}
+
+var linkRules = []linkRule{
+	// "#1234" and "owner/repo#1234"
+	{
+		re: regexp.MustCompile(`(^|[^\w/#&])(([\w.-]+/[\w.-]+)?#(\d+))\b`),
+		url: func(m Model, match []string) string {
+			owner, repo := m.owner, m.repo
+			if match[3] != "" {
+				owner, repo, _ = strings.Cut(match[3], "/")
+			}
+			return m.webURL + owner + "/" + repo + "/issues/" + match[4]
+		},
+	},
+	// "@username"
+	{
+		re: regexp.MustCompile(`(^|[^\w@/.])(@([A-Za-z0-9][A-Za-z0-9-]*))\b`),
+		url: func(m Model, match []string) string {
+			return m.profileURL(match[3])
+		},
+	},
+}
+
+// profileURL links an account on the forge being browsed.
+func (m Model) profileURL(login string) string {
+	return m.webURL + login
+}
+
+// trailingPadRe matches the spaces glamour pads lines with, keeping any
+// escape sequences that follow them.
+var trailingPadRe = regexp.MustCompile(` +((?:\x1b\[[0-9;]*m)*)$`)
+
+// hyperlink wraps text in an OSC 8 terminal hyperlink to url.
+func hyperlink(url, text string) string {
+	return "\x1b]8;;" + url + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+}
+
+// linkify turns issue references and @mentions in the visible body into
+// hyperlinks.
+//
+// It runs on the viewport's output, after width handling, because the
+// ANSI parsing lipgloss and Bubble Tea use to measure and truncate lines
+// doesn't understand OSC sequences and counts most of the URL as visible
+// text. A link is only added while the line still measures within width,
+// so nothing downstream decides to truncate it.
+func (m Model) linkify(view string, width int) string {
+	if !m.hyperlinks {
+		return view
+	}
+
+	lines := strings.Split(view, "\n")
+	for i, line := range lines {
+		if nextLink(line) != nil {
+			lines[i] = m.linkifyLine(trailingPadRe.ReplaceAllString(line, "$1"), width)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+type linkMatch struct {
+	rule *linkRule
+	loc  []int
+}
+
+// nextLink finds the leftmost reference in s matched by any rule.
+func nextLink(s string) *linkMatch {
+	var best *linkMatch
+
+	for i := range linkRules {
+		loc := linkRules[i].re.FindStringSubmatchIndex(s)
+		if loc != nil && (best == nil || loc[4] < best.loc[4]) {
+			best = &linkMatch{&linkRules[i], loc}
+		}
+	}
+
+	return best
+}
+
+func (m Model) linkifyLine(line string, width int) string {
+	var b strings.Builder
+	rest := line
+
+	for {
+		match := nextLink(rest)
+		if match == nil {
+			break
+		}
+
+		loc := match.loc
+		groups := make([]string, len(loc)/2)
+		for g := range groups {
+			if loc[2*g] >= 0 {
+				groups[g] = rest[loc[2*g]:loc[2*g+1]]
+			}
+		}
+
+		url := match.rule.url(m, groups)
+		linked := b.String() + rest[:loc[4]] + hyperlink(url, groups[2])
+
+		if lipgloss.Width(linked+rest[loc[5]:]) > width {
+			break
+		}
+
+		b.Reset()
+		b.WriteString(linked)
+		rest = rest[loc[5]:]
+	}
+
+	return b.String() + rest
+}
@@ -0,0 +1,162 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// listPaneWidth is how wide the release list is in the list layout.
+func listPaneWidth(width int) int {
+	return clamp(width/3, 24, 44)
+}
+
+// notesWidth is how wide the notes are: the whole window, or what the
+// release list leaves of it in the list layout.
+func (m Model) notesWidth() int {
+	if !m.listLayout {
+		return m.viewport.Width
+	}
+	return max(1, m.viewport.Width-listPaneWidth(m.viewport.Width)-1)
+}
+
+// toggleListLayout switches between the timeline and the list of releases
+// beside the notes.
+func (m *Model) toggleListLayout() tea.Cmd {
+	m.listLayout = !m.listLayout
+	return m.rerender()
+}
+
+// rerender drops the rendered notes, which are wrapped to fit the notes
+// pane, after it changed width, and shows the focused ones again.
+func (m *Model) rerender() tea.Cmd {
+	m.rendered = newBodyCache(defaultRenderedCacheBytes)
+	m.shownTag = ""
+	return m.showFocused()
+}
+
+// renderWrapped renders md wrapped to width columns, rather than the 80
+// glamour wraps to by default.
+func (m Model) renderWrapped(md string, width int) (string, error) {
+	r, err := glamour.NewTermRenderer(glamour.WithStylePath(m.style), glamour.WithWordWrap(width))
+	if err != nil {
+		return "", err
+	}
+	return r.Render(md)
+}
+
+// listKey moves through the list with the arrow keys, newest at the top;
+// the notes scroll with the paging keys and the mouse instead.
+func (m *Model) listKey(msg tea.KeyMsg) (tea.Cmd, bool) {
+	switch msg.String() {
+	case "up", "k":
+		return m.focusRelease(m.nextShown(m.focus, 1)), true
+	case "down", "j":
+		return m.focusRelease(m.nextShown(m.focus, -1)), true
+	}
+	return nil, false
+}
+
+// listView is the body in the list layout: the releases, newest first,
+// beside the focused one's notes.
+func (m Model) listView() string {
+	notes := m.viewport
+	notes.Width = m.notesWidth()
+
+	divider := strings.TrimSuffix(strings.Repeat("│\n", max(m.viewport.Height, 1)), "\n")
+
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		m.releaseColumn(),
+		releaseStyle.Render(divider),
+		m.linkify(m.stickyTitle(m.highlightLink(m.highlight(notes.View())), notes.Width), notes.Width),
+	)
+}
+
+// releaseColumn lists the visible releases with their names and dates,
+// scrolled to keep the focused one in view.
+func (m Model) releaseColumn() string {
+	width, height := listPaneWidth(m.viewport.Width), max(m.viewport.Height, 1)
+	row := lipgloss.NewStyle().Width(width).MaxWidth(width)
+
+	n := len(m.tagList)
+	first := m.listFirstRow()
+
+	latest, current := m.latestStable(), m.versionIndex()
+	now := time.Now()
+	lines := make([]string, 0, height)
+
+	for r := first; r < min(n, first+height); r++ {
+		i := n - 1 - r
+		tag := m.tagList[i].Original()
+		release := m.releases[tag]
+
+		marker := m.reviews.status(m.repoKey(), tag).marker()
+		if i == current {
+			marker = "⌂"
+		}
+		if tag == m.mark {
+			marker = "▴"
+		}
+
+		date := ""
+		if !release.Published.IsZero() {
+			date = release.Published.Local().Format("2006-01-02")
+		}
+
+		label := m.releaseLabel(tag, width-len(date)-4)
+
+		style := glyphStyles[ageStyle(release.Published, now)]
+		switch {
+		case i == m.focus:
+			style = focusStyle
+		case m.filtered(i):
+			style = oldStyle
+		case i == latest:
+			style = latestStyle
+		case m.breaking[tag]:
+			style = breakingStyle
+		}
+
+		cursor := " "
+		if i == m.focus {
+			cursor = "▸"
+		}
+
+		text := fmt.Sprintf("%s%s %-*s %s", cursor, marker, width-len(date)-4, label, date)
+		lines = append(lines, row.Render(style.Render(text)))
+	}
+
+	for len(lines) < height {
+		lines = append(lines, row.Render(""))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// listFirstRow is the row of the release list, counting from the newest
+// release, shown at the top of the column.
+func (m Model) listFirstRow() int {
+	n, height := len(m.tagList), max(m.viewport.Height, 1)
+	focusRow := n - 1 - m.focus
+	return clamp(focusRow-height/2, 0, max(0, n-height))
+}
+
+// truncate shortens s to width columns, ending it with … when cut.
+func truncate(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if lipgloss.Width(s) <= width {
+		return s
+	}
+
+	runes := []rune(s)
+	for len(runes) > 0 && lipgloss.Width(string(runes)) > width-1 {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// metadataView is the strip under the tag header: when the focused release
+// was published and by whom, its draft and prerelease badges, and how many
+// assets and reactions it has. It's always one line, blank without a
+// focused release, so the header keeps its height.
+func (m Model) metadataView() string {
+	r, ok := m.focusedRelease()
+	if !ok {
+		return ""
+	}
+
+	badges := []string{}
+	if r.Draft {
+		badges = append(badges, warningStyle.Render(" draft "))
+	}
+	if r.Prerelease || m.tagList[m.focus].Prerelease() != "" {
+		badges = append(badges, offlineStyle.Render(" prerelease "))
+	}
+
+	parts := []string{}
+	if !r.Published.IsZero() {
+		parts = append(parts, fmt.Sprintf("%s (%s)", r.Published.Local().Format("2006-01-02"), age(time.Since(r.Published))))
+	}
+	if r.Author != "" {
+		parts = append(parts, "by @"+r.Author)
+	}
+	parts = append(parts, plural(len(r.Assets), "asset"))
+	if r.Reactions > 0 {
+		parts = append(parts, plural(r.Reactions, "reaction"))
+	}
+
+	strip := " " + releaseStyle.Render(strings.Join(parts, " · "))
+	if len(badges) > 0 {
+		strip = " " + strings.Join(badges, " ") + strip
+	}
+
+	// a wrapped strip would push the notes down a line
+	return lipgloss.NewStyle().MaxWidth(m.viewport.Width).Render(strip)
+}
+
+// age says roughly how long ago something was, in the largest unit that
+// fits.
+func age(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return "just now"
+	case d < 24*time.Hour:
+		return plural(int(d/time.Hour), "hour") + " ago"
+	case d < month:
+		return plural(int(d/(24*time.Hour)), "day") + " ago"
+	case d < 12*month:
+		return plural(int(d/month), "month") + " ago"
+	default:
+		return plural(int(d/(365*24*time.Hour)), "year") + " ago"
+	}
+}
+
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
@@ -0,0 +1,178 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// updateMouse focuses the release clicked on the timeline or in the list,
+// steps through releases with the wheel over either, and follows links
+// clicked in the notes. It reports whether it handled msg; anything else,
+// the wheel over the notes included, is left to the viewport.
+func (m Model) updateMouse(msg tea.MouseMsg) (Model, tea.Cmd, bool) {
+	if len(m.tagList) == 0 {
+		return m, nil, false
+	}
+
+	inBody := msg.Y >= m.viewport.YPosition && msg.Y < m.viewport.YPosition+m.viewport.Height
+	inList := m.listLayout && inBody && msg.X < listPaneWidth(m.viewport.Width)
+
+	switch msg.Type {
+	case tea.MouseWheelUp, tea.MouseWheelDown:
+		step := 0
+		switch {
+		case msg.Y < m.viewport.YPosition:
+			// the timeline runs oldest to newest, left to right
+			step = 1
+			if msg.Type == tea.MouseWheelUp {
+				step = -1
+			}
+		case inList:
+			// the list runs newest to oldest, top to bottom
+			step = -1
+			if msg.Type == tea.MouseWheelUp {
+				step = 1
+			}
+		default:
+			return m, nil, false
+		}
+		return m, m.focusRelease(m.nextShown(m.focus, step)), true
+
+	case tea.MouseLeft:
+		row := lipgloss.Height(m.Title())
+		switch {
+		case msg.Y == row || msg.Y == row+1:
+			if i := m.timelineIndex(msg.X); i >= 0 {
+				return m, m.focusRelease(i), true
+			}
+			return m, nil, true
+		case inList:
+			if r := m.listFirstRow() + msg.Y - m.viewport.YPosition; r < len(m.tagList) {
+				return m, m.focusRelease(len(m.tagList) - 1 - r), true
+			}
+			return m, nil, true
+		case inBody:
+			x := msg.X
+			if m.listLayout {
+				x -= listPaneWidth(m.viewport.Width) + 1
+			}
+			if url, ok := m.linkAt(msg.Y-m.viewport.YPosition+m.viewport.YOffset, x); ok {
+				updated, cmd := m.followURL(url)
+				return updated, cmd, true
+			}
+		}
+	}
+
+	return m, nil, false
+}
+
+// timelineIndex is the release drawn at column x of the timeline, or -1.
+// The ◀ and ▶ columns of a windowed timeline stand for the release just
+// beyond them.
+func (m Model) timelineIndex(x int) int {
+	start, end, windowed := m.visibleWindow()
+
+	overflow := 0
+	if windowed {
+		overflow = m.overflowWidth()
+	}
+
+	// releaseList centers the glyphs, one column each, in the window
+	width := end - start + 2*overflow
+	col := x - max(0, (m.viewport.Width-width)/2)
+
+	switch {
+	case col < 0 || col >= width:
+		return -1
+	case col < overflow:
+		if start > 0 {
+			return start - 1
+		}
+		return -1
+	case col >= width-overflow:
+		if end < len(m.tagList) {
+			return end
+		}
+		return -1
+	}
+	return start + col - overflow
+}
+
+// linkAt finds the link at column col of line in the rendered notes: a
+// web address, an issue reference or @mention, or the text of a link in
+// the markdown.
+func (m Model) linkAt(line, col int) (string, bool) {
+	out, ok := m.rendered.Get(m.shownTag)
+	if !ok || m.shownTag == "" || col < 0 {
+		return "", false
+	}
+
+	lines := strings.Split(out, "\n")
+	if line < 0 || line >= len(lines) {
+		return "", false
+	}
+
+	text := stripANSI(lines[line])
+	at := columnOffset(text, col)
+	if at < 0 {
+		return "", false
+	}
+
+	for _, loc := range noteLinkRe.FindAllStringSubmatchIndex(text, -1) {
+		if loc[6] >= 0 && loc[6] <= at && at < loc[7] {
+			return strings.TrimRight(text[loc[6]:loc[7]], ".,;:!?'\""), true
+		}
+	}
+
+	for i := range linkRules {
+		rule := &linkRules[i]
+		for _, loc := range rule.re.FindAllStringSubmatchIndex(text, -1) {
+			if loc[4] > at || at >= loc[5] {
+				continue
+			}
+
+			groups := make([]string, len(loc)/2)
+			for g := range groups {
+				if loc[2*g] >= 0 {
+					groups[g] = text[loc[2*g]:loc[2*g+1]]
+				}
+			}
+			return rule.url(m, groups), true
+		}
+	}
+
+	raw, _ := m.raw.Get(m.shownTag)
+	for _, l := range noteLinks(raw) {
+		if l.text == "" {
+			continue
+		}
+		for from := 0; ; {
+			i := strings.Index(text[from:], l.text)
+			if i < 0 {
+				break
+			}
+			i += from
+			if i <= at && at < i+len(l.text) {
+				return l.url, true
+			}
+			from = i + len(l.text)
+		}
+	}
+
+	return "", false
+}
+
+// columnOffset is the byte offset in s of the character drawn at column
+// col, or -1 past its end.
+func columnOffset(s string, col int) int {
+	w := 0
+	for i, r := range s {
+		w += lipgloss.Width(string(r))
+		if col < w {
+			return i
+		}
+	}
+	return -1
+}
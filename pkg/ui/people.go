@@ -0,0 +1,203 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rubysolo/brows/pkg/releases"
+)
+
+// peoplePane lists people: either the new contributors across the
+// browsed range (every release after the current version up to the
+// focused one), or everyone mentioned in the focused release.
+type peoplePane struct {
+	open     bool
+	mentions bool
+	cursor   int
+	people   []releases.Contributor
+}
+
+// browsedRange returns the tags after the current version up to and
+// including the focused release, oldest first.
+func (m Model) browsedRange() []string {
+	tags := []string{}
+	for i := 0; i <= m.focus && i < len(m.tagList); i++ {
+		if m.tagList[i].GreaterThan(m.version) {
+			tags = append(tags, m.tagList[i].Original())
+		}
+	}
+	return tags
+}
+
+// openPeople lists the new contributors across the browsed range, or who
+// the focused release mentions. Notes evicted from the cache are fetched
+// again, and the list redone with them in extra when they arrive.
+func (m *Model) openPeople(mentions bool, extra map[string]string) tea.Cmd {
+	tags := m.browsedRange()
+	if mentions {
+		tags = []string{m.tagList[m.focus].Original()}
+	}
+	bodies, missing := m.cachedBodies(tags, extra)
+
+	cursor := 0
+	if extra != nil {
+		cursor = m.people.cursor
+	}
+
+	m.people = peoplePane{open: true, mentions: mentions}
+	if mentions {
+		m.people.people = collectMentions(bodies[tags[0]])
+	} else {
+		m.people.people = collectPeople(tags, bodies)
+	}
+	m.people.cursor = min(cursor, max(0, len(m.people.people)-1))
+
+	cmds := []tea.Cmd{m.fetchNames(logins(m.people.people))}
+	if len(missing) > 0 && extra == nil {
+		cmds = append(cmds, m.refetchBodies(refetchPeople, missing))
+	}
+	return tea.Batch(cmds...)
+}
+
+// collectPeople lists the new contributors in the notes of tags, most
+// credited first.
+func collectPeople(tags []string, bodies map[string]string) []releases.Contributor {
+	index := map[string]int{}
+	people := []releases.Contributor{}
+	credits := map[string]int{}
+
+	for _, tag := range tags {
+		body := bodies[tag]
+
+		for _, c := range releases.NewContributors(body) {
+			if _, seen := index[c.Login]; seen {
+				continue
+			}
+			c.Release = tag
+			index[c.Login] = len(people)
+			people = append(people, c)
+		}
+
+		for login, n := range releases.Credits(body) {
+			credits[login] += n
+		}
+	}
+
+	for i := range people {
+		people[i].Contributions = credits[people[i].Login]
+	}
+
+	sort.SliceStable(people, func(i, j int) bool {
+		return people[i].Contributions > people[j].Contributions
+	})
+
+	return people
+}
+
+// collectMentions lists everyone @mentioned in a release's notes, most
+// mentioned first.
+func collectMentions(body string) []releases.Contributor {
+	people := []releases.Contributor{}
+	for login, n := range releases.Mentions(body) {
+		people = append(people, releases.Contributor{Login: login, Contributions: n})
+	}
+
+	sort.SliceStable(people, func(i, j int) bool {
+		if people[i].Contributions != people[j].Contributions {
+			return people[i].Contributions > people[j].Contributions
+		}
+		return people[i].Login < people[j].Login
+	})
+
+	return people
+}
+
+func logins(people []releases.Contributor) []string {
+	out := make([]string, len(people))
+	for i, c := range people {
+		out[i] = c.Login
+	}
+	return out
+}
+
+func (m Model) updatePeople(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, tea.Quit
+
+	case "P", "@", "esc":
+		m.people.open = false
+
+	case "up", "k":
+		if m.people.cursor > 0 {
+			m.people.cursor--
+		}
+
+	case "down", "j":
+		if m.people.cursor < len(m.people.people)-1 {
+			m.people.cursor++
+		}
+
+	case "o", "enter":
+		if m.people.cursor < len(m.people.people) {
+			return m, openURL(m.profileURL(m.people.people[m.people.cursor].Login))
+		}
+
+	case "O":
+		if m.people.cursor < len(m.people.people) {
+			if pr := m.people.people[m.people.cursor].FirstPR; pr != "" {
+				return m, openURL(pr)
+			}
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) peopleView() string {
+	if len(m.people.people) == 0 && m.people.mentions {
+		return screenCentered(m.viewport.Width, m.viewport.Height).Render("Nobody is mentioned in this release.")
+	}
+
+	if len(m.people.people) == 0 {
+		return screenCentered(m.viewport.Width, m.viewport.Height).Render("No new contributors in this range.")
+	}
+
+	var title string
+	if m.people.mentions {
+		title = fmt.Sprintf("  %d people mentioned in %s", len(m.people.people), m.tagList[m.focus].Original())
+	} else {
+		tags := m.browsedRange()
+		title = fmt.Sprintf("  %d new contributors from %s to %s", len(m.people.people), tags[0], tags[len(tags)-1])
+	}
+
+	lines := []string{"", title, ""}
+
+	for i, c := range m.people.people {
+		name := m.enriched.names[c.Login]
+
+		var line string
+		if m.people.mentions {
+			line = fmt.Sprintf("%-24s %-28s %3d  %s", "@"+c.Login, name, c.Contributions, m.profileURL(c.Login))
+		} else {
+			line = fmt.Sprintf("%-24s %-28s %3d  first in %-12s %s", "@"+c.Login, name, c.Contributions, c.Release, m.profileURL(c.Login))
+		}
+
+		if i == m.people.cursor {
+			line = focusStyle.Render("▸ " + line)
+		} else {
+			line = "  " + line
+		}
+		lines = append(lines, line)
+	}
+
+	lines = append(lines, "", releaseStyle.Render("  o open profile · O open first PR · esc close"))
+
+	for len(lines) < m.viewport.Height {
+		lines = append(lines, "")
+	}
+
+	return strings.Join(lines[:max(m.viewport.Height, 0)], "\n")
+}
@@ -0,0 +1,125 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/rubysolo/brows/pkg/store"
+	"gopkg.in/yaml.v3"
+)
+
+type reviewStatus string
+
+const (
+	unreviewed reviewStatus = ""
+	reviewed   reviewStatus = "reviewed"
+	approved   reviewStatus = "approved"
+	skipped    reviewStatus = "skipped"
+)
+
+// next cycles through the review states in the order they are usually
+// applied while working through an upgrade plan.
+func (s reviewStatus) next() reviewStatus {
+	switch s {
+	case unreviewed:
+		return reviewed
+	case reviewed:
+		return approved
+	case approved:
+		return skipped
+	default:
+		return unreviewed
+	}
+}
+
+func (s reviewStatus) marker() string {
+	switch s {
+	case reviewed:
+		return "•"
+	case approved:
+		return "✓"
+	case skipped:
+		return "✗"
+	default:
+		return " "
+	}
+}
+
+const reviewBucket = store.BucketReviews
+
+// legacyReviewStatePath is where review state lived before the store.
+const legacyReviewStatePath = ".local/state/brows/reviews.yml"
+
+// ReviewState holds the review status of each tag, per "owner/repo",
+// writing changes through to the store.
+type ReviewState struct {
+	st    *store.Store
+	repos map[string]map[string]reviewStatus
+}
+
+func ReadReviewState(st *store.Store) *ReviewState {
+	s := &ReviewState{st: st, repos: make(map[string]map[string]reviewStatus)}
+
+	keys := st.Keys(reviewBucket, "")
+	if len(keys) == 0 {
+		s.migrateLegacy()
+		return s
+	}
+
+	for _, repo := range keys {
+		tags := make(map[string]reviewStatus)
+		if _, err := st.Get(reviewBucket, repo, &tags); err == nil {
+			s.repos[repo] = tags
+		}
+	}
+
+	return s
+}
+
+// migrateLegacy imports a reviews.yml left by an older brows.
+func (s *ReviewState) migrateLegacy() {
+	dirname, _ := os.UserHomeDir()
+	path := filepath.Join(dirname, legacyReviewStatePath)
+
+	f, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	legacy := map[string]map[string]reviewStatus{}
+	if yaml.NewDecoder(f).Decode(&legacy) != nil {
+		return
+	}
+
+	for repo, tags := range legacy {
+		s.repos[repo] = tags
+		if err := s.st.Put(reviewBucket, repo, tags); err != nil {
+			return
+		}
+	}
+
+	f.Close()
+	os.Remove(path)
+}
+
+func (s *ReviewState) status(repo, tag string) reviewStatus {
+	return s.repos[repo][tag]
+}
+
+func (s *ReviewState) set(repo, tag string, status reviewStatus) error {
+	if s.repos[repo] == nil {
+		s.repos[repo] = make(map[string]reviewStatus)
+	}
+
+	if status == unreviewed {
+		delete(s.repos[repo], tag)
+	} else {
+		s.repos[repo][tag] = status
+	}
+
+	if len(s.repos[repo]) == 0 {
+		return s.st.Delete(reviewBucket, repo)
+	}
+	return s.st.Put(reviewBucket, repo, s.repos[repo])
+}
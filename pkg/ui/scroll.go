@@ -0,0 +1,116 @@
+package ui
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rubysolo/brows/pkg/releases"
+)
+
+// pagerKeyMap keeps the viewport to the keys pagers agree on, leaving
+// letters free for brows's own commands. Line scrolling is handled in
+// scrollKey so its step can be configured.
+func pagerKeyMap() viewport.KeyMap {
+	return viewport.KeyMap{
+		PageDown:     key.NewBinding(key.WithKeys("pgdown", " ")),
+		PageUp:       key.NewBinding(key.WithKeys("pgup")),
+		HalfPageDown: key.NewBinding(key.WithKeys("ctrl+d")),
+		HalfPageUp:   key.NewBinding(key.WithKeys("ctrl+u")),
+		Down:         key.NewBinding(key.WithDisabled()),
+		Up:           key.NewBinding(key.WithDisabled()),
+	}
+}
+
+// scrollKey scrolls the body by the configured step, reporting whether
+// msg was a line-scrolling key.
+func (m *Model) scrollKey(msg tea.KeyMsg) bool {
+	switch msg.String() {
+	case "down", "j":
+		m.viewport.LineDown(m.scrollStep)
+	case "up", "k":
+		m.viewport.LineUp(m.scrollStep)
+	default:
+		return false
+	}
+	return true
+}
+
+// setContent replaces the body. With scroll past end enabled, the body is
+// padded so its last line can be scrolled to the top of the viewport.
+func (m *Model) setContent(s string) {
+	if m.scrollPastEnd && s != "" {
+		s = strings.TrimRight(s, "\n") + strings.Repeat("\n", max(0, m.viewport.Height-1))
+	}
+	m.viewport.SetContent(s)
+}
+
+// showBody puts a release's rendered notes in the viewport. A release
+// that wasn't already showing starts at the top, or at its breaking
+// changes heading when JumpToBreaking is set.
+func (m *Model) showBody(tag, out string) {
+	m.setContent(out)
+
+	if tag == m.shownTag {
+		return
+	}
+	m.shownTag = tag
+
+	m.viewport.GotoTop()
+	if m.jumpBreaking {
+		raw, _ := m.raw.Get(tag)
+		if line := renderedLine(out, releases.BreakingHeading(raw)); line >= 0 {
+			m.viewport.SetYOffset(line)
+		}
+	}
+}
+
+var ansiRe = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+func stripANSI(s string) string {
+	return ansiRe.ReplaceAllString(s, "")
+}
+
+// renderedLine returns the first line of rendered output containing text,
+// or -1.
+func renderedLine(out, text string) int {
+	if text == "" {
+		return -1
+	}
+
+	for i, line := range strings.Split(out, "\n") {
+		if strings.Contains(stripANSI(line), text) {
+			return i
+		}
+	}
+	return -1
+}
+
+// releaseLabel is a release's title, cut down to width.
+func (m Model) releaseLabel(tag string, width int) string {
+	r, ok := m.releases[tag]
+	if !ok {
+		return truncate(tag, width)
+	}
+	return truncate(r.Title(), width)
+}
+
+// stickyTitle pins the shown release's tag and name over the first line of
+// the notes once they're scrolled, so a long release is never anonymous.
+func (m Model) stickyTitle(view string, width int) string {
+	if m.viewport.YOffset == 0 || m.shownTag == "" || m.overlayOpen() && !m.capturingInput() {
+		return view
+	}
+
+	label := focusStyle.Render(" " + m.releaseLabel(m.shownTag, width-4) + " ")
+	rule := releaseStyle.Render(strings.Repeat("─", max(0, width-lipgloss.Width(label)-1)))
+
+	first, rest, _ := strings.Cut(view, "\n")
+	if first == "" && rest == "" {
+		return view
+	}
+	return releaseStyle.Render("─") + label + rule + "\n" + rest
+}
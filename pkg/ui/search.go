@@ -0,0 +1,284 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// search finds a query in the loaded releases: in their tags and titles,
+// and line by line in their rendered notes.
+type search struct {
+	active bool
+	input  textinput.Model
+	query  string
+
+	// hits are every match, in timeline order; current indexes the one
+	// last jumped to, or is -1.
+	hits    []searchHit
+	current int
+}
+
+// searchHit is a line of a release's rendered notes matching the query.
+// A release matching only by tag or title has a hit on line 0.
+type searchHit struct {
+	tag  string
+	line int
+}
+
+func newSearchInput() textinput.Model {
+	input := textinput.New()
+	input.Prompt = "/"
+	input.Placeholder = "search tags, titles and notes"
+	return input
+}
+
+func (m Model) updateSearchInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+
+	case "esc":
+		m.search.active = false
+		m.search.input.Blur()
+		return m, nil
+
+	case "enter":
+		m.search.active = false
+		m.search.input.Blur()
+		m.search.query = m.search.input.Value()
+
+		// notes evicted from the cache are searched once they're back
+		hits, missing := m.findHits(m.search.query, nil)
+		m.search.hits, m.search.current = hits, -1
+		refetch := m.refetchBodies(refetchSearch, missing)
+		if len(hits) == 0 && refetch != nil {
+			return m, refetch
+		}
+		return m, tea.Batch(m.nextHit(1), refetch)
+	}
+
+	var cmd tea.Cmd
+	m.search.input, cmd = m.search.input.Update(msg)
+	return m, cmd
+}
+
+// renderedBody returns a release's rendered notes, rendering them now
+// if they're only in the raw cache, or in extra, and could match q.
+// Rendering is slow, so notes that can't match are left unrendered; ok
+// is false only when the notes aren't at hand at all.
+func (m Model) renderedBody(tag, q string, extra map[string]string) (string, bool) {
+	if out, ok := m.rendered.Get(tag); ok {
+		return out, true
+	}
+
+	body, ok := m.raw.Get(tag)
+	if !ok {
+		body, ok = extra[tag]
+	}
+	if !ok {
+		return "", false
+	}
+
+	// milestone details are left for when the release is shown
+	body, _ = m.enrich(tag, body)
+	if !mayMatch(body, q) {
+		return "", true
+	}
+
+	out := m.render(body)
+	m.rendered.Put(tag, out)
+
+	return out, true
+}
+
+// mayMatch reports whether notes written as the markdown md could match
+// q, in lower case, once rendered. Rendering drops markup but doesn't add
+// words, so every word of q is in md already.
+func mayMatch(md, q string) bool {
+	md = strings.ToLower(md)
+	for _, word := range strings.Fields(q) {
+		if !strings.Contains(md, word) {
+			return false
+		}
+	}
+	return true
+}
+
+// findHits lists every match of query across the loaded releases, oldest
+// release first, reading notes evicted from the caches from extra. It
+// also returns the releases whose notes it couldn't search.
+func (m Model) findHits(query string, extra map[string]string) ([]searchHit, []string) {
+	if query == "" {
+		return nil, nil
+	}
+
+	q := strings.ToLower(query)
+	hits := []searchHit{}
+	missing := []string{}
+
+	for _, v := range m.tagList {
+		tag := v.Original()
+		r := m.releases[tag]
+		titleHit := strings.Contains(strings.ToLower(tag), q) || strings.Contains(strings.ToLower(r.Name), q)
+
+		out, ok := m.renderedBody(tag, q, extra)
+		if !ok {
+			// body evicted; the tag and title can still match
+			missing = append(missing, tag)
+			if titleHit {
+				hits = append(hits, searchHit{tag: tag})
+			}
+			continue
+		}
+
+		found := false
+		for i, line := range strings.Split(out, "\n") {
+			if strings.Contains(strings.ToLower(stripANSI(line)), q) {
+				hits = append(hits, searchHit{tag: tag, line: i})
+				found = true
+			}
+		}
+
+		if titleHit && !found {
+			hits = append(hits, searchHit{tag: tag})
+		}
+	}
+
+	return hits, missing
+}
+
+// nextHit jumps to the next (dir 1) or previous (dir -1) match, moving to
+// another release when the focused one has no more, and wrapping around.
+func (m *Model) nextHit(dir int) tea.Cmd {
+	if m.search.query == "" {
+		return nil
+	}
+
+	if len(m.search.hits) == 0 {
+		m.status = "no match for " + m.search.query
+		return nil
+	}
+
+	n := len(m.search.hits)
+	i := m.search.current
+	if i < 0 || m.focus < 0 || m.search.hits[i].tag != m.tagList[m.focus].Original() {
+		// start from the focused release, as if the last hit was just
+		// before (or after) it
+		i = m.hitNearFocus(dir)
+	} else {
+		i = ((i+dir)%n + n) % n
+	}
+	m.search.current = i
+
+	hit := m.search.hits[i]
+	if index, ok := m.tagIndex[hit.tag]; ok && index != m.focus {
+		m.focus = index
+		m.navSeq++
+	}
+
+	cmd := m.showFocused()
+	m.viewport.SetYOffset(hit.line)
+	m.status = fmt.Sprintf("%s: match %d of %d", m.search.query, i+1, n)
+
+	return cmd
+}
+
+// hitNearFocus is the first hit in or after the focused release going
+// forward, or the last one in or before it going backward.
+func (m Model) hitNearFocus(dir int) int {
+	n := len(m.search.hits)
+
+	if dir > 0 {
+		for i, h := range m.search.hits {
+			if m.tagIndex[h.tag] >= m.focus {
+				return i
+			}
+		}
+		return 0
+	}
+
+	for i := n - 1; i >= 0; i-- {
+		if m.tagIndex[m.search.hits[i].tag] <= m.focus {
+			return i
+		}
+	}
+	return n - 1
+}
+
+// highlight shows matches of the query in the visible body in reverse
+// video. Like linkify, it runs on the viewport's output; reverse video
+// doesn't change widths, but the styles inside a match may reset it, so
+// it's turned back on after each escape sequence.
+func (m Model) highlight(view string) string {
+	if m.search.query == "" {
+		return view
+	}
+
+	lines := strings.Split(view, "\n")
+	for i, line := range lines {
+		lines[i] = highlightLine(line, m.search.query)
+	}
+	return strings.Join(lines, "\n")
+}
+
+const (
+	reverseOn  = "\x1b[7m"
+	reverseOff = "\x1b[27m"
+)
+
+func highlightLine(line, query string) string {
+	plain := stripANSI(line)
+	lower, q := strings.ToLower(plain), strings.ToLower(query)
+	if !strings.Contains(lower, q) || len(lower) != len(plain) {
+		// nothing to do, or case folding changed byte offsets
+		return line
+	}
+
+	// mark which bytes of the plain text fall inside a match
+	inMatch := make([]bool, len(plain))
+	for start := 0; ; {
+		i := strings.Index(lower[start:], q)
+		if i < 0 {
+			break
+		}
+		for j := start + i; j < start+i+len(q); j++ {
+			inMatch[j] = true
+		}
+		start += i + len(q)
+	}
+
+	var b strings.Builder
+	pos, on := 0, false
+	for len(line) > 0 {
+		if loc := ansiRe.FindStringIndex(line); loc != nil && loc[0] == 0 {
+			b.WriteString(line[:loc[1]])
+			if on {
+				b.WriteString(reverseOn)
+			}
+			line = line[loc[1]:]
+			continue
+		}
+
+		want := inMatch[pos]
+		if want != on {
+			if want {
+				b.WriteString(reverseOn)
+			} else {
+				b.WriteString(reverseOff)
+			}
+			on = want
+		}
+
+		b.WriteByte(line[0])
+		line = line[1:]
+		pos++
+	}
+	if on {
+		b.WriteString(reverseOff)
+	}
+
+	return b.String()
+}
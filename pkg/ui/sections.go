@@ -0,0 +1,208 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rubysolo/brows/pkg/releases"
+)
+
+// sectionFolds records the sections of release notes folded or unfolded
+// by hand, by tag and then by the line of their heading. The rest follow
+// the default: dependency updates folded, everything else open.
+type sectionFolds map[string]map[int]bool
+
+func (m Model) sectionFolded(tag string, s releases.Section) bool {
+	if folded, ok := m.folds[tag][s.Start]; ok {
+		return folded
+	}
+	return s.IsDependencies()
+}
+
+// shownSections are the sections of tag's notes that aren't inside a
+// folded one.
+func (m Model) shownSections(tag, body string) []releases.Section {
+	shown := []releases.Section{}
+	end := 0
+	for _, s := range releases.Sections(body) {
+		if s.Start < end {
+			continue
+		}
+		shown = append(shown, s)
+		if m.sectionFolded(tag, s) {
+			end = s.End
+		}
+	}
+	return shown
+}
+
+// foldSections cuts the folded sections of tag's notes down to their
+// headings, each saying how much it hides.
+func (m Model) foldSections(tag, body string) string {
+	lines := strings.Split(body, "\n")
+	out := make([]string, 0, len(lines))
+
+	next := 0
+	for _, s := range m.shownSections(tag, body) {
+		if !m.sectionFolded(tag, s) {
+			continue
+		}
+		hidden := foldedCount(lines[s.Start+1 : s.End])
+		if hidden == "" {
+			continue
+		}
+		out = append(out, lines[next:s.Start]...)
+		out = append(out, lines[s.Start]+" ▸ "+hidden+" folded")
+		next = s.End
+	}
+
+	return strings.Join(append(out, lines[next:]...), "\n")
+}
+
+// foldedCount describes what a folded section hides: its list entries,
+// or its lines if it has none. It's "" for an empty section.
+func foldedCount(lines []string) string {
+	entries, text := 0, 0
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+		case line == strings.TrimLeft(line, " \t") && len(trimmed) > 1 && strings.ContainsRune("-*+", rune(trimmed[0])) && trimmed[1] == ' ':
+			entries++
+			text++
+		default:
+			text++
+		}
+	}
+
+	switch {
+	case entries == 1:
+		return "1 entry"
+	case entries > 1:
+		return fmt.Sprintf("%d entries", entries)
+	case text == 1:
+		return "1 line"
+	case text > 1:
+		return fmt.Sprintf("%d lines", text)
+	}
+	return ""
+}
+
+// sectionLines places sections in rendered notes: the line each heading
+// is on, or -1 when it couldn't be found.
+func sectionLines(out string, sections []releases.Section) []int {
+	lines := strings.Split(out, "\n")
+	at := make([]int, len(sections))
+
+	from := 0
+	for i, s := range sections {
+		at[i] = -1
+		for j := from; j < len(lines); j++ {
+			if strings.Contains(stripANSI(lines[j]), s.Title) {
+				at[i], from = j, j+1
+				break
+			}
+		}
+	}
+	return at
+}
+
+// noteSections are the shown release's sections and where they are in
+// its rendered notes.
+func (m Model) noteSections() ([]releases.Section, []int) {
+	if m.focus < 0 || m.shownTag != m.tagList[m.focus].Original() {
+		return nil, nil
+	}
+
+	raw, _ := m.raw.Get(m.shownTag)
+	out, _ := m.rendered.Get(m.shownTag)
+	sections := m.shownSections(m.shownTag, raw)
+	return sections, sectionLines(out, sections)
+}
+
+// topLine is the first line of the notes not under the sticky title,
+// where a heading jumped to is put.
+func (m Model) topLine() int {
+	if m.viewport.YOffset == 0 {
+		return 0
+	}
+	return m.viewport.YOffset + 1
+}
+
+// jumpSection scrolls the next (dir 1) or previous (dir -1) heading in
+// the notes to the top.
+func (m *Model) jumpSection(dir int) {
+	sections, at := m.noteSections()
+
+	top, target := m.topLine(), -1
+	for i := range sections {
+		if at[i] < 0 {
+			continue
+		}
+		if dir > 0 && at[i] > top {
+			target = i
+			break
+		}
+		if dir < 0 && at[i] < top {
+			target = i
+		}
+	}
+
+	switch {
+	case len(sections) == 0:
+		m.status = "no sections in these notes"
+		return
+	case target < 0 && dir > 0:
+		m.status = "no more sections"
+		return
+	case target < 0:
+		m.viewport.GotoTop()
+		return
+	}
+
+	m.viewport.SetYOffset(max(0, at[target]-1))
+	m.status = fmt.Sprintf("%s · section %d of %d", sections[target].Title, target+1, len(sections))
+}
+
+// toggleSection folds or unfolds the section at the top of the notes:
+// the last heading at or above the first line shown.
+func (m *Model) toggleSection() tea.Cmd {
+	sections, at := m.noteSections()
+
+	current := -1
+	for i := range sections {
+		if at[i] >= 0 && at[i] <= max(m.topLine(), 1) {
+			current = i
+		}
+	}
+	if current < 0 {
+		m.status = "no section here to fold; { and } move between them"
+		return nil
+	}
+
+	tag, s := m.shownTag, sections[current]
+	folded := !m.sectionFolded(tag, s)
+	if m.folds == nil {
+		m.folds = make(sectionFolds)
+	}
+	if m.folds[tag] == nil {
+		m.folds[tag] = make(map[int]bool)
+	}
+	m.folds[tag][s.Start] = folded
+
+	m.rendered.Delete(tag)
+	cmd := m.showFocused()
+
+	// the sections before this one haven't moved, so neither has it
+	if _, at := m.noteSections(); current < len(at) && at[current] >= 0 && m.viewport.YOffset > 0 {
+		m.viewport.SetYOffset(max(0, at[current]-1))
+	}
+
+	if folded {
+		m.status = s.Title + " folded"
+	} else {
+		m.status = s.Title + " unfolded"
+	}
+	return cmd
+}
@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rubysolo/brows/pkg/releases"
+)
+
+// Defaults for Options.StaleMajors and Options.StaleMonths.
+const (
+	defaultStaleMajors = 2
+	defaultStaleMonths = 12
+)
+
+const month = 30 * 24 * time.Hour
+
+// behindLatest measures how far the current version is behind the loaded
+// releases. ok is false when no version was given, so there's nothing to
+// be behind.
+func (m Model) behindLatest() (releases.Behind, bool) {
+	if m.version.Original() == "0.0.0" {
+		return releases.Behind{}, false
+	}
+
+	list := make([]releases.Release, 0, len(m.releases))
+	for _, r := range m.releases {
+		list = append(list, r)
+	}
+
+	behind, err := releases.Compare(m.version.Original(), list)
+	return behind, err == nil
+}
+
+// stalenessWarning returns the banner shown when the current version is
+// more than staleMajors major releases or staleMonths months behind the
+// latest release, or "" if it's recent enough.
+func (m Model) stalenessWarning() string {
+	behind, ok := m.behind, m.behindKnown
+	if !ok || behind.UpToDate() {
+		return ""
+	}
+
+	reasons := []string{}
+	if m.staleMajors > 0 && behind.Major > m.staleMajors {
+		reasons = append(reasons, fmt.Sprintf("%d majors", behind.Major))
+	}
+	if months := int(behind.Lag / month); m.staleMonths > 0 && months > m.staleMonths {
+		reasons = append(reasons, fmt.Sprintf("%d months", months))
+	}
+	if len(reasons) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("⚠ %s behind %s", strings.Join(reasons, " and "), behind.Latest)
+}
+
+// staleThreshold applies the default to a zero threshold; negative ones
+// turn the check off.
+func staleThreshold(v, def int) int {
+	if v == 0 {
+		return def
+	}
+	return v
+}
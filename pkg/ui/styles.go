@@ -0,0 +1,76 @@
+package ui
+
+import "github.com/charmbracelet/lipgloss"
+
+var (
+	titleStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#E0E0E0")).
+			Background(lipgloss.Color("#0066CC"))
+
+	tagStyle = func() lipgloss.Style {
+		b := lipgloss.RoundedBorder()
+		b.Right = "├"
+		return lipgloss.NewStyle().BorderStyle(b).Padding(0, 1)
+	}()
+
+	infoStyle = func() lipgloss.Style {
+		b := lipgloss.RoundedBorder()
+		b.Left = "┤"
+		return tagStyle.Copy().BorderStyle(b)
+	}()
+
+	hCentered = func(w int) lipgloss.Style {
+		return lipgloss.NewStyle().
+			Width(w).
+			Align(lipgloss.Center)
+	}
+
+	screenCentered = func(w, h int) lipgloss.Style {
+		return lipgloss.NewStyle().
+			Width(w).
+			Align(lipgloss.Center).
+			Height(h).
+			AlignVertical(lipgloss.Center)
+	}
+
+	warningStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#1A1A1A")).
+			Background(lipgloss.Color("#FFB000"))
+
+	offlineStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#E0E0E0")).
+			Background(lipgloss.Color("#5C5C5C"))
+
+	focusStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00"))
+	releaseStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#5C5C5C"))
+	breakingStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFB000"))
+	latestStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#00AFFF"))
+
+	// release ages on the timeline, newest brightest; a year old is
+	// releaseStyle
+	freshStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#F0F0F0"))
+	recentStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#A0A0A0"))
+	oldStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("#3A3A3A"))
+)
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func clamp(v, low, high int) int {
+	if high < low {
+		low, high = high, low
+	}
+	return min(high, max(low, v))
+}
@@ -0,0 +1,348 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/masterminds/semver"
+	"github.com/rubysolo/brows/pkg/releases"
+	"github.com/rubysolo/brows/pkg/store"
+)
+
+// visit is the history entry kept for each browsed repository.
+type visit struct {
+	Version string    `json:"version"`
+	At      time.Time `json:"at"`
+}
+
+// recordVisit notes that the current repository was browsed, so the
+// quick switcher can offer it later.
+func (m Model) recordVisit() error {
+	return m.store.Put(store.BucketHistory, m.repoKey(), visit{Version: m.version.Original(), At: time.Now()})
+}
+
+// pinned reports whether repo is pinned, either here or in the config.
+func (m Model) pinned(repo string) bool {
+	if ok, _ := m.store.Get(store.BucketPins, repo, &struct{}{}); ok {
+		return true
+	}
+	for _, p := range m.configPins {
+		if p == repo {
+			return true
+		}
+	}
+	return false
+}
+
+// togglePin pins or unpins the current repository.
+func (m *Model) togglePin() {
+	repo := m.repoKey()
+
+	for _, p := range m.configPins {
+		if p == repo {
+			m.status = repo + " is pinned in the config file"
+			return
+		}
+	}
+
+	if m.pinned(repo) {
+		if err := m.store.Delete(store.BucketPins, repo); err != nil {
+			m.status = "could not unpin: " + err.Error()
+			return
+		}
+		m.status = "unpinned " + repo
+		return
+	}
+
+	if err := m.store.Put(store.BucketPins, repo, struct{}{}); err != nil {
+		m.status = "could not pin: " + err.Error()
+		return
+	}
+	m.status = "pinned " + repo
+}
+
+// switchTarget is one entry in the quick switcher.
+type switchTarget struct {
+	label string // what the user types to find it
+	repo  string // owner/repo
+	kind  string // pin, alias, recent or starred
+	note  string // shown in place of the kind, when set
+	at    time.Time
+}
+
+// switcher is the ctrl+p menu for jumping to another repository.
+type switcher struct {
+	active  bool
+	input   textinput.Model
+	cursor  int
+	targets []switchTarget
+	matches []switchTarget
+
+	// newTab opens the chosen repository in another tab rather than
+	// browsing it here.
+	newTab bool
+}
+
+func newSwitcherInput() textinput.Model {
+	input := textinput.New()
+	input.Prompt = "→ "
+	input.Placeholder = "pinned, aliased or recent repo, or owner/repo"
+	return input
+}
+
+// switchTargets collects pins first, then aliases, then history from most
+// recently browsed, skipping repositories already listed.
+func (m Model) switchTargets() []switchTarget {
+	return collectTargets(m.store, m.configPins, m.aliases)
+}
+
+func collectTargets(st *store.Store, configPins []string, aliasMap map[string]string) []switchTarget {
+	seen := map[string]bool{}
+	targets := []switchTarget{}
+
+	add := func(t switchTarget) {
+		if seen[t.label] {
+			return
+		}
+		seen[t.label] = true
+		targets = append(targets, t)
+	}
+
+	pins := append([]string{}, configPins...)
+	pins = append(pins, st.Keys(store.BucketPins, "")...)
+	for _, p := range pins {
+		add(switchTarget{label: p, repo: p, kind: "pin"})
+	}
+
+	aliases := make([]string, 0, len(aliasMap))
+	for name := range aliasMap {
+		aliases = append(aliases, name)
+	}
+	sort.Strings(aliases)
+	for _, name := range aliases {
+		add(switchTarget{label: name, repo: aliasMap[name], kind: "alias"})
+	}
+
+	recent := []switchTarget{}
+	for _, repo := range st.Keys(store.BucketHistory, "") {
+		v := visit{}
+		if ok, err := st.Get(store.BucketHistory, repo, &v); ok && err == nil {
+			recent = append(recent, switchTarget{label: repo, repo: repo, kind: "recent", at: v.At})
+		}
+	}
+	sort.SliceStable(recent, func(i, j int) bool { return recent[i].at.After(recent[j].at) })
+	for _, t := range recent {
+		add(t)
+	}
+
+	return targets
+}
+
+// lastVersion is the version repo was last browsed at, or 0.0.0 when it
+// never was.
+func lastVersion(st *store.Store, repo string) *semver.Version {
+	v := visit{}
+	if ok, err := st.Get(store.BucketHistory, repo, &v); ok && err == nil {
+		if last, err := semver.NewVersion(v.Version); err == nil {
+			return last
+		}
+	}
+	version, _ := semver.NewVersion("0.0.0")
+	return version
+}
+
+// fuzzyScore reports whether every character of query appears in s in
+// order, and how tightly: lower is better. Consecutive characters and a
+// match right after a separator score best.
+func fuzzyScore(query, s string) (int, bool) {
+	query, s = strings.ToLower(query), strings.ToLower(s)
+
+	score, last := 0, -1
+	for _, c := range query {
+		i := strings.IndexRune(s[last+1:], c)
+		if i < 0 {
+			return 0, false
+		}
+		i += last + 1
+
+		gap := i - last - 1
+		if last >= 0 || gap > 0 {
+			if i > 0 && strings.ContainsRune("/-_.", rune(s[i-1])) {
+				gap = min(gap, 1)
+			}
+			score += gap
+		}
+		last = i
+	}
+
+	return score + len(s) - len(query), true
+}
+
+func (sw *switcher) filter() {
+	query := sw.input.Value()
+
+	type scored struct {
+		target switchTarget
+		score  int
+	}
+	matches := []scored{}
+	for _, t := range sw.targets {
+		if query == "" {
+			matches = append(matches, scored{t, 0})
+			continue
+		}
+		if score, ok := fuzzyScore(query, t.label); ok {
+			matches = append(matches, scored{t, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score < matches[j].score })
+
+	sw.matches = make([]switchTarget, len(matches))
+	for i, s := range matches {
+		sw.matches[i] = s.target
+	}
+	sw.cursor = clamp(sw.cursor, 0, max(0, len(sw.matches)-1))
+}
+
+func (m Model) openSwitcher() (Model, tea.Cmd) {
+	m.switcher = switcher{active: true, input: newSwitcherInput(), targets: m.switchTargets()}
+	m.switcher.filter()
+	return m, m.switcher.input.Focus()
+}
+
+func (m Model) updateSwitcher(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+
+	case "esc", "ctrl+p":
+		m.switcher.active = false
+		return m, nil
+
+	case "up", "ctrl+k":
+		if m.switcher.cursor > 0 {
+			m.switcher.cursor--
+		}
+		return m, nil
+
+	case "down", "ctrl+j":
+		if m.switcher.cursor < len(m.switcher.matches)-1 {
+			m.switcher.cursor++
+		}
+		return m, nil
+
+	case "enter":
+		m.switcher.active = false
+
+		repo := ""
+		if m.switcher.cursor < len(m.switcher.matches) {
+			repo = m.switcher.matches[m.switcher.cursor].repo
+		} else if typed := strings.TrimSpace(m.switcher.input.Value()); strings.Count(typed, "/") == 1 {
+			repo = typed
+		}
+		if repo == "" {
+			return m, nil
+		}
+		if m.switcher.newTab {
+			return m, func() tea.Msg { return openTab{repo} }
+		}
+
+		return m.switchTo(repo)
+	}
+
+	var cmd tea.Cmd
+	m.switcher.input, cmd = m.switcher.input.Update(msg)
+	m.switcher.filter()
+	return m, cmd
+}
+
+// switchTo starts browsing repo ("owner/repo") in place of the current
+// repository, from the version it was last browsed at.
+func (m Model) switchTo(repo string) (tea.Model, tea.Cmd) {
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok || owner == "" || name == "" {
+		m.status = "not a repository: " + repo
+		return m, nil
+	}
+	if repo == m.repoKey() {
+		return m, nil
+	}
+
+	m.owner, m.repo, m.version, m.until = owner, name, lastVersion(m.store, repo), nil
+	m.load = newLoad(repo)
+	m.constraint, m.constraintSpec, m.outside = nil, "", make(map[string]*semver.Version)
+	m.loaded = false
+	m.failure = nil
+	m.focus = -1
+	m.releases = make(map[string]releases.Release)
+	m.tagList = []*semver.Version{}
+	m.glyphs, m.tagIndex, m.breaking = nil, nil, nil
+	m.raw = newBodyCache(defaultRawCacheBytes)
+	m.rendered = newBodyCache(defaultRenderedCacheBytes)
+	m.shownTag = ""
+	m.staleWarning = ""
+	m.behind, m.behindKnown = releases.Behind{}, false
+	m.loadDone, m.loadTotal = 0, 0
+	m.loadingMore, m.autoFocus = false, ""
+	m.navSeq++
+	m.assets = assetsPanel{}
+	m.people = peoplePane{}
+	m.deprecations = deprecationsPane{}
+	m.filter = keywordFilter{}
+	m.folds = nil
+	m.discussion = discussionPane{}
+	m.commits = commitsPane{}
+	m.file = filePane{}
+	m.links = linkFocus{}
+	m.mark = ""
+	m.transfer = transfer{}
+	m.aggregate = false
+	m.search.query, m.search.hits = "", nil
+	m.setContent("")
+
+	return m, getReleases(m.provider, m.owner, m.repo, m.load)
+}
+
+func (m Model) switcherView() string {
+	heading := "  Switch repository"
+	if m.switcher.newTab {
+		heading = "  Open repository in a new tab"
+	}
+	lines := []string{"", heading, ""}
+
+	if len(m.switcher.matches) == 0 {
+		lines = append(lines, releaseStyle.Render("  no matches; enter owner/repo to open it directly"))
+	}
+
+	for i, t := range m.switcher.matches {
+		label := t.label
+		if t.kind == "alias" {
+			label = fmt.Sprintf("%s → %s", t.label, t.repo)
+		}
+
+		marker := " "
+		if t.kind == "pin" {
+			marker = "★"
+		}
+
+		line := fmt.Sprintf("%s %-48s %s", marker, label, releaseStyle.Render(t.kind))
+		if i == m.switcher.cursor {
+			line = focusStyle.Render("▸ ") + line
+		} else {
+			line = "  " + line
+		}
+		lines = append(lines, line)
+	}
+
+	lines = append(lines, "", releaseStyle.Render("  ↑/↓ select · enter switch · esc close"))
+
+	for len(lines) < m.viewport.Height {
+		lines = append(lines, "")
+	}
+
+	return strings.Join(lines[:max(m.viewport.Height, 0)], "\n")
+}
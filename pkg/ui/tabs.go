@@ -0,0 +1,212 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Tabs browses several repositories at once, one Model per tab, each
+// with its own timeline, version and scroll position. The tab bar only
+// appears once there's more than one.
+type Tabs struct {
+	tabs   []Model
+	active int
+
+	// size is the last window size, replayed to tabs opened later
+	size tea.WindowSizeMsg
+}
+
+// NewTabs opens a tab for each model, the first one active.
+func NewTabs(models ...Model) Tabs {
+	return Tabs{tabs: models}
+}
+
+// openTab asks for a new tab browsing repo ("owner/repo").
+type openTab struct{ repo string }
+
+func (t Tabs) Init() tea.Cmd {
+	cmds := make([]tea.Cmd, len(t.tabs))
+	for i, m := range t.tabs {
+		cmds[i] = m.Init()
+	}
+	return tea.Batch(cmds...)
+}
+
+// Err returns the error that stopped the program, if any tab hit one.
+func (t Tabs) Err() error {
+	for _, m := range t.tabs {
+		if m.Err() != nil {
+			return m.Err()
+		}
+	}
+	return nil
+}
+
+// tabSize is the window size left to each tab under the tab bar.
+func (t Tabs) tabSize() tea.WindowSizeMsg {
+	size := t.size
+	if len(t.tabs) > 1 {
+		size.Height--
+	}
+	return size
+}
+
+func (t Tabs) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		t.size = msg
+		return t.broadcast(t.tabSize())
+
+	case openTab:
+		for i, m := range t.tabs {
+			if m.repoKey() == msg.repo {
+				t.active = i
+				return t, nil
+			}
+		}
+		return t.open(msg.repo)
+
+	case tea.KeyMsg:
+		// with a single tab, tab is left to step through links
+		if len(t.tabs) > 1 && !t.tabs[t.active].capturingInput() {
+			switch msg.String() {
+			case "tab":
+				t.active = (t.active + 1) % len(t.tabs)
+				return t, nil
+			case "shift+tab":
+				t.active = (t.active + len(t.tabs) - 1) % len(t.tabs)
+				return t, nil
+			case "ctrl+w":
+				return t.close()
+			}
+		}
+		return t.updateActive(msg)
+
+	case tea.MouseMsg:
+		if len(t.tabs) > 1 {
+			if msg.Y == 0 {
+				if msg.Type == tea.MouseLeft {
+					if i := t.tabAt(msg.X); i >= 0 {
+						t.active = i
+					}
+				}
+				return t, nil
+			}
+			// the tabs are drawn below the tab bar
+			msg.Y--
+		}
+		return t.updateActive(msg)
+	}
+
+	// a loader streams through a channel that must be read once per
+	// message, so what comes through it goes only to the tab it's for
+	if cmd := drain(msg); cmd != nil {
+		for i, m := range t.tabs {
+			if m.streamsTo(msg) {
+				updated, cmd := m.Update(msg)
+				t.tabs[i] = updated.(Model)
+				return t, cmd
+			}
+		}
+		// its tab closed, or moved on to another load
+		return t, cmd
+	}
+
+	// everything else may be the reply to any tab's request; each tab
+	// drops what isn't for its repository
+	return t.broadcast(msg)
+}
+
+func (t Tabs) updateActive(msg tea.Msg) (tea.Model, tea.Cmd) {
+	updated, cmd := t.tabs[t.active].Update(msg)
+	t.tabs[t.active] = updated.(Model)
+	return t, cmd
+}
+
+func (t Tabs) broadcast(msg tea.Msg) (tea.Model, tea.Cmd) {
+	cmds := make([]tea.Cmd, len(t.tabs))
+	for i, m := range t.tabs {
+		updated, cmd := m.Update(msg)
+		t.tabs[i] = updated.(Model)
+		cmds[i] = cmd
+	}
+	return t, tea.Batch(cmds...)
+}
+
+// open adds a tab browsing repo after the others, with the same settings
+// as the active one.
+func (t Tabs) open(repo string) (tea.Model, tea.Cmd) {
+	m := t.tabs[t.active]
+	m.spinner = newSpinner()
+	m.switcher = switcher{}
+
+	updated, cmd := m.switchTo(repo)
+	t.tabs = append(t.tabs, updated.(Model))
+	t.active = len(t.tabs) - 1
+
+	// the tab bar takes a line from every tab when the second one opens
+	_, resize := t.broadcast(t.tabSize())
+	return t, tea.Batch(cmd, m.spinner.Tick, resize)
+}
+
+// close closes the active tab, unless it's the last one.
+func (t Tabs) close() (tea.Model, tea.Cmd) {
+	if len(t.tabs) == 1 {
+		return t, nil
+	}
+
+	t.tabs = append(t.tabs[:t.active:t.active], t.tabs[t.active+1:]...)
+	t.active = min(t.active, len(t.tabs)-1)
+
+	return t.broadcast(t.tabSize())
+}
+
+func (t Tabs) View() string {
+	if len(t.tabs) == 1 {
+		return t.tabs[0].View()
+	}
+	return t.tabBar() + "\n" + t.tabs[t.active].View()
+}
+
+// tabAt is the tab labelled at column x of the tab bar, or -1, which it
+// also is while the bar is too narrow to label them all.
+func (t Tabs) tabAt(x int) int {
+	labels := make([]string, len(t.tabs))
+	for i, m := range t.tabs {
+		labels[i] = " " + m.repoKey() + " "
+	}
+	if lipgloss.Width(strings.Join(labels, "│")) > t.size.Width {
+		return -1
+	}
+
+	left := 0
+	for i, label := range labels {
+		right := left + lipgloss.Width(label)
+		if x >= left && x < right {
+			return i
+		}
+		left = right + 1
+	}
+	return -1
+}
+
+func (t Tabs) tabBar() string {
+	labels := make([]string, len(t.tabs))
+	for i, m := range t.tabs {
+		if i == t.active {
+			labels[i] = titleStyle.Render(" " + m.repoKey() + " ")
+		} else {
+			labels[i] = releaseStyle.Render(" " + m.repoKey() + " ")
+		}
+	}
+
+	bar := strings.Join(labels, releaseStyle.Render("│"))
+	if lipgloss.Width(bar) > t.size.Width {
+		// too many to label; show the active one and its position
+		bar = titleStyle.Render(" " + t.tabs[t.active].repoKey() + " ")
+		bar += releaseStyle.Render(" " + strings.Repeat("·", t.active) + "•" + strings.Repeat("·", len(t.tabs)-t.active-1))
+	}
+	return bar
+}
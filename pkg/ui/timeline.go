@@ -0,0 +1,383 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/masterminds/semver"
+	"github.com/rubysolo/brows/pkg/releases"
+)
+
+// glyphStyle names the style a timeline cell is drawn in. Styles are
+// referred to by key so adjacent cells sharing one can be rendered in a
+// single lipgloss call.
+type glyphStyle int
+
+const (
+	glyphRelease glyphStyle = iota
+	glyphFocus
+	glyphBreaking
+	glyphLatest
+	glyphFresh
+	glyphRecent
+	glyphOld
+)
+
+var glyphStyles = map[glyphStyle]lipgloss.Style{
+	glyphRelease:  releaseStyle,
+	glyphFocus:    focusStyle,
+	glyphBreaking: breakingStyle,
+	glyphLatest:   latestStyle,
+	glyphFresh:    freshStyle,
+	glyphRecent:   recentStyle,
+	glyphOld:      oldStyle,
+}
+
+// ageStyle shades a release by how long ago it was published: the newer,
+// the brighter.
+func ageStyle(published, now time.Time) glyphStyle {
+	if published.IsZero() {
+		return glyphRelease
+	}
+
+	switch age := now.Sub(published); {
+	case age < month:
+		return glyphFresh
+	case age < 6*month:
+		return glyphRecent
+	case age < 12*month:
+		return glyphRelease
+	default:
+		return glyphOld
+	}
+}
+
+type cell struct {
+	text  string
+	style glyphStyle
+}
+
+// visibleTags sorts the tags of the loaded releases the timeline shows:
+// all of them, or only stable ones while prereleases are hidden. Tags
+// that aren't semantic versions can't be placed on it, and are returned
+// separately.
+func (m Model) visibleTags() (semver.Collection, []string) {
+	tags := make([]string, 0, len(m.releases))
+	for tag, r := range m.releases {
+		if m.stableOnly && !r.Stable() {
+			continue
+		}
+		tags = append(tags, tag)
+	}
+	return releases.SplitTags(tags)
+}
+
+// addReleases merges newly arrived releases into the timeline, keeping
+// only the requested range: after the current version up to and including
+// until. Bodies go to the raw cache; the release map only keeps metadata.
+// It returns the tags left off the timeline because they aren't versions.
+func (m *Model) addReleases(list map[string]releases.Release) []string {
+	focused := ""
+	if m.focus >= 0 {
+		focused = m.tagList[m.focus].Original()
+	}
+
+	if m.breaking == nil {
+		m.breaking = make(map[string]bool)
+	}
+	inRange := releases.VersionRange{From: m.version, To: m.until}
+
+	for tag, r := range list {
+		if m.until != nil || m.constraint != nil {
+			v, err := semver.NewVersion(tag)
+			if err != nil || m.until != nil && !inRange.Contains(v) {
+				continue
+			}
+			if m.constraint != nil && !m.constraint.Check(v) {
+				m.outside[tag] = v
+				continue
+			}
+		}
+
+		m.breaking[tag] = releases.IsBreaking(r.Description)
+		if m.filter.keyword != "" {
+			m.filter.matches[tag] = r.Matches(m.filter.keyword)
+		}
+		m.raw.Put(tag, r.Description)
+		r.Description = ""
+		m.releases[tag] = r
+	}
+
+	var other []string
+	m.tagList, other = m.visibleTags()
+	m.glyphs, m.tagIndex = indexTags(m.tagList)
+	m.loaded = true
+
+	// stay on the same release while the indexes shift under it
+	m.focus = -1
+	if i, ok := m.tagIndex[focused]; ok {
+		m.focus = i
+	}
+
+	return other
+}
+
+// beyondConstraint marks where the constraint stops: how many stable
+// releases newer than any satisfying it there are, and the first of them.
+func (m Model) beyondConstraint() string {
+	if m.constraint == nil || !m.loaded {
+		return ""
+	}
+
+	var first *semver.Version
+	count := 0
+	for _, v := range m.outside {
+		if v.Prerelease() != "" || len(m.tagList) > 0 && !v.GreaterThan(m.tagList[len(m.tagList)-1]) {
+			continue
+		}
+		count++
+		if first == nil || v.LessThan(first) {
+			first = v
+		}
+	}
+
+	switch count {
+	case 0:
+		return ""
+	case 1:
+		return fmt.Sprintf("%s is outside %s", first.Original(), m.constraintSpec)
+	}
+	return fmt.Sprintf("%d newer releases outside %s, from %s", count, m.constraintSpec, first.Original())
+}
+
+// latestStable is the index of the newest release on the timeline that
+// isn't a prerelease or draft, or -1.
+func (m Model) latestStable() int {
+	for i := len(m.tagList) - 1; i >= 0; i-- {
+		if m.tagList[i].Prerelease() == "" && m.releases[m.tagList[i].Original()].Stable() {
+			return i
+		}
+	}
+	return -1
+}
+
+// versionIndex is where the current version is on the timeline: its
+// release, or the newest one before it when it wasn't released under that
+// tag. It's -1 without a current version, or when it's off the timeline.
+func (m Model) versionIndex() int {
+	if m.version.Original() == "0.0.0" {
+		return -1
+	}
+
+	i := sort.Search(len(m.tagList), func(i int) bool { return m.tagList[i].GreaterThan(m.version) })
+	return i - 1
+}
+
+// placeFocus puts the focus on the first release after the current
+// version, unless the user has moved elsewhere while releases were still
+// arriving.
+func (m *Model) placeFocus() error {
+	if m.focus >= 0 && m.tagList[m.focus].Original() != m.autoFocus {
+		return nil
+	}
+
+	index, err := releases.FindTagIndex(m.version, m.tagList)
+	if err != nil {
+		return err
+	}
+
+	m.focus = index
+	m.autoFocus = m.tagList[index].Original()
+	return nil
+}
+
+// otherTagsNote tells which tags were left off the timeline.
+func otherTagsNote(other []string) string {
+	const shown = 3
+
+	list := strings.Join(other[:min(len(other), shown)], ", ")
+	if len(other) > shown {
+		list += fmt.Sprintf(" and %d more", len(other)-shown)
+	}
+	return fmt.Sprintf("%d tags aren't versions and are left off the timeline: %s", len(other), list)
+}
+
+// togglePrereleases shows or hides prereleases and drafts, staying on the
+// focused release, or the next one shown if it was just hidden.
+func (m *Model) togglePrereleases() tea.Cmd {
+	if !m.loaded {
+		return nil
+	}
+
+	var focused *semver.Version
+	if m.focus >= 0 {
+		focused = m.tagList[m.focus]
+	}
+
+	m.stableOnly = !m.stableOnly
+	m.tagList, _ = m.visibleTags()
+	m.glyphs, m.tagIndex = indexTags(m.tagList)
+
+	if m.stableOnly {
+		m.status = "prereleases hidden"
+	} else {
+		m.status = "prereleases shown"
+	}
+
+	if focused != nil {
+		if i, ok := m.tagIndex[focused.Original()]; ok {
+			m.focus = i
+		} else if i, err := releases.FindTagIndex(focused, m.tagList); err == nil {
+			m.focus = i
+		} else {
+			m.focus = len(m.tagList) - 1
+		}
+	}
+	if m.focus < 0 {
+		m.setContent("")
+	}
+
+	m.search.hits, _ = m.findHits(m.search.query, nil)
+	m.search.current = -1
+	m.navSeq++
+
+	return m.showFocused()
+}
+
+// indexTags classifies every tag once when releases load, so drawing the
+// timeline only touches the visible window and finding a tag's position
+// is a map lookup.
+func indexTags(tagList semver.Collection) ([]string, map[string]int) {
+	glyphs := make([]string, len(tagList))
+	index := make(map[string]int, len(tagList))
+
+	for i, t := range tagList {
+		glyphs[i] = glyphFor(t)
+		index[t.Original()] = i
+	}
+
+	return glyphs, index
+}
+
+// render as major/minor/patch
+func glyphFor(t *semver.Version) string {
+	switch {
+	case releases.IsMajor(t):
+		return "▇"
+	case releases.IsMinor(t):
+		return "▅"
+	case releases.IsPatch(t):
+		return "▂"
+	default:
+		return "."
+	}
+}
+
+// overflowWidth is how wide the ◀/▶ columns at either end of a windowed
+// timeline are: the arrow, with the count of releases beyond it below.
+func (m Model) overflowWidth() int {
+	return len(fmt.Sprint(len(m.tagList))) + 1
+}
+
+// visibleWindow returns the slice of the timeline that fits the viewport,
+// and whether it had to be cut down to fit. A cut down window slides to
+// keep the focused release in the middle, until it reaches either end.
+func (m Model) visibleWindow() (start, end int, windowed bool) {
+	if len(m.tagList) <= m.viewport.Width {
+		return 0, len(m.tagList), false
+	}
+
+	size := max(1, m.viewport.Width-2*m.overflowWidth())
+	start = clamp(m.focus-size/2, 0, len(m.tagList)-size)
+	end = min(len(m.tagList), start+size)
+
+	return start, end, true
+}
+
+func (m Model) releaseList() string {
+	start, end, windowed := m.visibleWindow()
+
+	glyphs := make([]cell, 0, end-start+2)
+	markers := make([]cell, 0, end-start+2)
+
+	width := m.overflowWidth()
+
+	if windowed {
+		if start > 0 {
+			glyphs = append(glyphs, cell{fmt.Sprintf("%-*s", width, "◀"), glyphRelease})
+			markers = append(markers, cell{fmt.Sprintf("%-*d", width, start), glyphRelease})
+		} else {
+			glyphs = append(glyphs, cell{strings.Repeat(" ", width), glyphRelease})
+			markers = append(markers, cell{strings.Repeat(" ", width), glyphRelease})
+		}
+	}
+
+	repoKey := m.repoKey()
+	latest, current := m.latestStable(), m.versionIndex()
+	now := time.Now()
+
+	for i := start; i < end; i++ {
+		tag := m.tagList[i].Original()
+
+		glyph := m.glyphs[i]
+		style := ageStyle(m.releases[tag].Published, now)
+		switch {
+		case i == m.focus:
+			style = glyphFocus
+		case m.filtered(i):
+			// dimmed down to a dot, but still clickable in its place
+			glyph, style = "·", glyphOld
+		case i == latest:
+			style = glyphLatest
+		case m.breaking[tag]:
+			style = glyphBreaking
+		}
+
+		marker := m.reviews.status(repoKey, m.tagList[i].Original()).marker()
+		if i == current {
+			marker = "⌂"
+		}
+		if m.tagList[i].Original() == m.mark {
+			marker = "▴"
+		}
+
+		glyphs = append(glyphs, cell{glyph, style})
+		markers = append(markers, cell{marker, style})
+	}
+
+	if windowed {
+		if end < len(m.tagList) {
+			glyphs = append(glyphs, cell{fmt.Sprintf("%*s", width, "▶"), glyphRelease})
+			markers = append(markers, cell{fmt.Sprintf("%*d", width, len(m.tagList)-end), glyphRelease})
+		} else {
+			glyphs = append(glyphs, cell{strings.Repeat(" ", width), glyphRelease})
+			markers = append(markers, cell{strings.Repeat(" ", width), glyphRelease})
+		}
+	}
+
+	// center in window
+	return hCentered(m.viewport.Width).Render(renderCells(glyphs) + "\n" + renderCells(markers))
+}
+
+// renderCells draws runs of same-styled cells with one Render call each.
+func renderCells(cells []cell) string {
+	var (
+		b   strings.Builder
+		run strings.Builder
+	)
+
+	for i, c := range cells {
+		run.WriteString(c.text)
+
+		if i == len(cells)-1 || cells[i+1].style != c.style {
+			b.WriteString(glyphStyles[c.style].Render(run.String()))
+			run.Reset()
+		}
+	}
+
+	return b.String()
+}
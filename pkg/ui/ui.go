@@ -0,0 +1,1229 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/charmbracelet/bubbles/progress"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/masterminds/semver"
+	reflowtruncate "github.com/muesli/reflow/truncate"
+	"github.com/rubysolo/brows/pkg/releases"
+	"github.com/rubysolo/brows/pkg/store"
+)
+
+// Model is the Bubble Tea model for browsing the releases of one repository.
+type Model struct {
+	owner         string
+	repo          string
+	version       *semver.Version
+	until         *semver.Version
+	focus         int
+	loaded        bool
+	releases      map[string]releases.Release
+	tagList       semver.Collection
+	glyphs        []string
+	breaking      map[string]bool
+	tagIndex      map[string]int
+	raw           *bodyCache
+	rendered      *bodyCache
+	enriched      *enrichment
+	hyperlinks    bool
+	scrollStep    int
+	scrollPastEnd bool
+	wheelLines    int
+	jumpBreaking  bool
+	style         string
+	stableOnly    bool
+	staleMajors   int
+	staleMonths   int
+	staleWarning  string
+	loadDone      int
+	// loadingMore is set while pages of releases are still arriving after
+	// the first ones were shown.
+	loadingMore bool
+	// autoFocus is the release the focus was put on when releases
+	// arrived, as opposed to one the user moved to.
+	autoFocus  string
+	loadTotal  int
+	shownTag   string
+	navSeq     int
+	assets     assetsPanel
+	people     peoplePane
+	discussion discussionPane
+	commits    commitsPane
+	file       filePane
+	links      linkFocus
+	mark       string // release d compares the focused one with
+	transfer   transfer
+	help       bool
+	listLayout bool
+	// expandIssues adds the titles and labels of the issues and pull
+	// requests the notes refer to.
+	expandIssues bool
+	// behind is how far the current version is behind, once every
+	// release has loaded.
+	behind         releases.Behind
+	behindKnown    bool
+	aggregate      bool
+	startAggregate bool
+	webURL         string
+	downloader     releases.Downloader
+	downloadDir    string
+	imageProtocol  string
+	search         search
+	switcher       switcher
+	jump           jumpPrompt
+	configPins     []string
+	aliases        map[string]string
+	store          *store.Store
+	offline        func() bool
+	anonymous      bool
+	rateLimit      func() (remaining, limit int, reset time.Time, ok bool)
+	status         string
+	provider       releases.Provider
+	spinner        spinner.Model
+	viewport       viewport.Model
+	viewReady      bool
+	reviews        *ReviewState
+	err            error
+	// failure is why the releases couldn't be loaded, shown until they're
+	// retried.
+	failure error
+	scopes  tokenScopes
+	// constraint, if set, is what the browsed releases satisfy, as
+	// written in constraintSpec; the releases that don't are kept in
+	// outside.
+	constraint     *semver.Constraints
+	constraintSpec string
+	outside        map[string]*semver.Version
+	deprecations   deprecationsPane
+	filter         keywordFilter
+	folds          sectionFolds
+	// load identifies the current load of the repository's releases;
+	// replies to any other are stale.
+	load string
+}
+
+// Options configures optional behavior of the Model.
+type Options struct {
+	// Store persists review state. A nil Store keeps it in memory only.
+	Store *store.Store
+
+	// NoHyperlinks turns off OSC 8 links for terminals that print them
+	// literally.
+	NoHyperlinks bool
+
+	// ScrollStep is how many lines up/down and j/k scroll. Defaults to 1.
+	ScrollStep int
+	// ScrollPastEnd lets the last line of a release scroll to the top.
+	ScrollPastEnd bool
+	// MouseWheelLines is how far one wheel notch scrolls. Defaults to 3.
+	MouseWheelLines int
+	// JumpToBreaking scrolls each newly focused release to its breaking
+	// changes or upgrade notes heading, if it has one.
+	JumpToBreaking bool
+
+	// Style is the glamour style notes are rendered in: a built-in name
+	// like "dark", "light" or "notty", or the path of a JSON stylesheet.
+	// Defaults to "dark".
+	Style string
+
+	// StableOnly hides prereleases and drafts from the timeline until
+	// they're toggled back on.
+	StableOnly bool
+
+	// Aggregate opens the combined notes of every release after the
+	// current version once they're loaded.
+	Aggregate bool
+
+	// Until, if set, hides the releases after it, so only those from
+	// the current version up to and including Until are browsed.
+	Until string
+
+	// StaleMajors and StaleMonths are how far the current version may
+	// fall behind the latest release, in major releases and in months,
+	// before a warning is shown. Zero uses the defaults (2 and 12);
+	// negative turns the check off.
+	StaleMajors int
+	StaleMonths int
+
+	// WebURL is the root of the forge's web interface, with a trailing
+	// slash, for links built from references in release notes. Defaults to
+	// https://github.com/.
+	WebURL string
+
+	// Downloader saves assets from the assets panel into DownloadDir,
+	// the current directory if empty.
+	Downloader  releases.Downloader
+	DownloadDir string
+
+	// Pins are repositories ("owner/repo") always offered first by the
+	// quick switcher, alongside the ones pinned with *.
+	Pins []string
+	// Aliases are short names the quick switcher matches, mapped to
+	// "owner/repo".
+	Aliases map[string]string
+
+	// Anonymous is set when there's no token, so failures can say one
+	// would help.
+	Anonymous bool
+	// Offline reports whether what's shown came from a local cache
+	// rather than the network, for a badge in the title.
+	Offline func() bool
+	// ListLayout starts with the releases listed beside the notes rather
+	// than on the timeline.
+	ListLayout bool
+
+	// ImageProtocol is the graphics protocol i previews images with:
+	// "kitty", "iterm2", "sixel" or "none". Empty or "auto" detects kitty
+	// and iTerm2.
+	ImageProtocol string
+
+	// RateLimit reports the API quota left and when it resets, for the
+	// footer. ok is false until the forge has reported one.
+	RateLimit func() (remaining, limit int, reset time.Time, ok bool)
+}
+
+// New builds a Model that browses owner/repo from provider, starting at the
+// first release after version. version can also be a constraint, like
+// "^1.4", for only the releases satisfying it to be browsed.
+func New(provider releases.Provider, owner, repo, version string, opts Options) (Model, error) {
+	constraint, isConstraint := releases.ParseConstraint(version)
+	spec := ""
+	if isConstraint {
+		spec, version = version, "0.0.0"
+	}
+
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return Model{}, fmt.Errorf("Error parsing current version %v", err)
+	}
+
+	var until *semver.Version
+	if opts.Until != "" {
+		if until, err = semver.NewVersion(opts.Until); err != nil {
+			return Model{}, fmt.Errorf("Error parsing range end %v", err)
+		}
+	}
+
+	if opts.Store == nil {
+		opts.Store, _ = store.Open("")
+	}
+	if opts.Style == "" {
+		opts.Style = "dark"
+	}
+	if opts.WebURL == "" {
+		opts.WebURL = "https://github.com/"
+	}
+
+	return Model{
+		load:           newLoad(owner + "/" + repo),
+		owner:          owner,
+		repo:           repo,
+		version:        v,
+		until:          until,
+		constraint:     constraint,
+		constraintSpec: spec,
+		outside:        make(map[string]*semver.Version),
+		startAggregate: opts.Aggregate,
+		loaded:         false,
+		releases:       make(map[string]releases.Release),
+		tagList:        []*semver.Version{},
+		focus:          -1,
+		provider:       provider,
+		spinner:        newSpinner(),
+		reviews:        ReadReviewState(opts.Store),
+		raw:            newBodyCache(defaultRawCacheBytes),
+		rendered:       newBodyCache(defaultRenderedCacheBytes),
+		enriched:       newEnrichment(),
+		hyperlinks:     !opts.NoHyperlinks,
+		search:         search{input: newSearchInput()},
+		scrollStep:     max(1, opts.ScrollStep),
+		scrollPastEnd:  opts.ScrollPastEnd,
+		wheelLines:     opts.MouseWheelLines,
+		jumpBreaking:   opts.JumpToBreaking,
+		style:          opts.Style,
+		stableOnly:     opts.StableOnly,
+		staleMajors:    staleThreshold(opts.StaleMajors, defaultStaleMajors),
+		staleMonths:    staleThreshold(opts.StaleMonths, defaultStaleMonths),
+		webURL:         opts.WebURL,
+		downloader:     opts.Downloader,
+		downloadDir:    opts.DownloadDir,
+		imageProtocol:  imageProtocol(opts.ImageProtocol),
+		configPins:     opts.Pins,
+		aliases:        opts.Aliases,
+		store:          opts.Store,
+		offline:        opts.Offline,
+		anonymous:      opts.Anonymous,
+		rateLimit:      opts.RateLimit,
+		listLayout:     opts.ListLayout,
+	}, nil
+}
+
+func newSpinner() spinner.Model {
+	spin := spinner.New()
+	spin.Spinner = spinner.Dot
+	spin.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
+	return spin
+}
+
+// capturingInput reports whether a prompt has the keyboard, so keys like
+// tab go to it rather than switching tabs.
+func (m Model) capturingInput() bool {
+	return m.search.active || m.switcher.active || m.jump.active || m.filter.active
+}
+
+// overlayOpen reports whether a prompt or panel is open over the notes,
+// which esc closes before anything else.
+func (m Model) overlayOpen() bool {
+	return m.capturingInput() || m.assets.open || m.people.open || m.deprecations.open || m.discussion.open || m.commits.open || m.file.open || m.help || m.aggregate
+}
+
+func (m Model) Init() tea.Cmd {
+	return tea.Batch(getReleases(m.provider, m.owner, m.repo, m.load), m.spinner.Tick, m.checkScopes())
+}
+
+// loads numbers the loads of releases across every tab, so the replies to
+// one that's been superseded, even by a load of the same repository, can
+// be told apart and dropped.
+var loads atomic.Int64
+
+// newLoad identifies a new load of repo ("owner/repo").
+func newLoad(repo string) string {
+	return fmt.Sprintf("%s#%d", repo, loads.Add(1))
+}
+
+// loadedReleases carries the releases of a load, which may no longer be
+// the current one.
+type loadedReleases struct {
+	load     string
+	releases map[string]releases.Release
+}
+
+// errMsg is a failure to load a repository's releases.
+type errMsg struct {
+	load string
+	err  error
+}
+
+func (e errMsg) Error() string { return e.err.Error() }
+func (e errMsg) Unwrap() error { return e.err }
+
+// Err returns the error that stopped the program, if any.
+func (m Model) Err() error {
+	return m.err
+}
+
+func getReleases(provider releases.Provider, owner, repo, load string) tea.Cmd {
+	if pager, ok := provider.(releases.PageLister); ok {
+		// each page is shown as it arrives, so it mustn't be dropped; the
+		// last message is the whole list
+		ch := make(chan tea.Msg, 1)
+		go func() {
+			releaseList, err := pager.ListReleasesByPage(context.Background(), owner, repo, func(list []releases.Release, done, total int) {
+				ch <- releasesPage{ch: ch, load: load, releases: list, done: done, total: total}
+			})
+			ch <- releasesMsg(load, releaseList, err)
+		}()
+
+		return waitForLoad(ch)
+	}
+
+	lister, ok := provider.(releases.ProgressLister)
+	if !ok {
+		return func() tea.Msg {
+			releaseList, err := provider.ListReleases(context.Background(), owner, repo)
+			return releasesMsg(load, releaseList, err)
+		}
+	}
+
+	// stream progress through a channel the Update loop keeps waiting on;
+	// the last message is the result
+	ch := make(chan tea.Msg, 1)
+	go func() {
+		releaseList, err := lister.ListReleasesWithProgress(context.Background(), owner, repo, func(done, total int) {
+			select {
+			case ch <- loadProgress{ch: ch, load: load, done: done, total: total}:
+			default:
+				// the UI hasn't caught up with the last update; skip this one
+			}
+		})
+		ch <- releasesMsg(load, releaseList, err)
+	}()
+
+	return waitForLoad(ch)
+}
+
+func releasesMsg(load string, releaseList []releases.Release, err error) tea.Msg {
+	if err != nil {
+		return errMsg{load: load, err: err}
+	}
+
+	loaded := loadedReleases{load: load, releases: make(map[string]releases.Release)}
+	for _, r := range releaseList {
+		loaded.releases[r.Tag] = r
+	}
+
+	return loaded
+}
+
+// releasesPage is one page of releases, shown while the rest load.
+type releasesPage struct {
+	ch          chan tea.Msg
+	load        string
+	releases    []releases.Release
+	done, total int
+}
+
+// loadProgress reports how many pages of releases have arrived.
+type loadProgress struct {
+	ch          chan tea.Msg
+	load        string
+	done, total int
+}
+
+func waitForLoad(ch chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return <-ch
+	}
+}
+
+// streamsTo reports whether msg, from a loader's channel, is for this
+// Model's current load or download.
+func (m Model) streamsTo(msg tea.Msg) bool {
+	switch msg := msg.(type) {
+	case releasesPage:
+		return msg.load == m.load
+	case loadProgress:
+		return msg.load == m.load
+	case downloadProgress:
+		return msg.repo == m.repoKey() && msg.asset == m.transfer.asset
+	}
+	return false
+}
+
+// drain keeps reading the channel a loader's message came through when
+// no Model is left to: the loader blocks on its next send otherwise.
+func drain(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case releasesPage:
+		return waitForLoad(msg.ch)
+	case loadProgress:
+		return waitForLoad(msg.ch)
+	case downloadProgress:
+		return waitForLoad(msg.ch)
+	}
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var (
+		cmd  tea.Cmd
+		cmds []tea.Cmd
+	)
+
+	switch msg := msg.(type) {
+	case loadedReleases:
+		if msg.load != m.load {
+			// switched away before these arrived
+			break
+		}
+
+		// got the whole list back: it replaces whatever pages were shown
+		// while it loaded
+		m.releases = make(map[string]releases.Release, len(msg.releases))
+		m.breaking = make(map[string]bool, len(msg.releases))
+		m.outside = make(map[string]*semver.Version)
+		m.loadingMore = false
+
+		other := m.addReleases(msg.releases)
+		m.behind, m.behindKnown = m.behindLatest()
+		m.staleWarning = m.stalenessWarning()
+
+		if len(other) > 0 {
+			m.status = otherTagsNote(other)
+		}
+
+		if err := m.recordVisit(); err != nil {
+			slog.Error("saving history", "err", err)
+		}
+
+		if err := m.placeFocus(); err != nil {
+			m.err = err
+		}
+
+		cmds = append(cmds, m.showFocused())
+
+		if m.startAggregate {
+			m.startAggregate = false
+			cmds = append(cmds, m.openAggregate(nil))
+		}
+
+	case releasesPage:
+		cmds = append(cmds, waitForLoad(msg.ch))
+		if msg.load != m.load {
+			break
+		}
+
+		m.loadDone, m.loadTotal = msg.done, msg.total
+		m.loadingMore = true
+
+		page := make(map[string]releases.Release, len(msg.releases))
+		for _, r := range msg.releases {
+			page[r.Tag] = r
+		}
+		m.addReleases(page)
+
+		// no newer release in what's arrived isn't an error until it's all in
+		if m.placeFocus() == nil {
+			cmds = append(cmds, m.showFocused())
+		}
+
+	case loadProgress:
+		if msg.load == m.load {
+			m.loadDone, m.loadTotal = msg.done, msg.total
+		}
+		cmds = append(cmds, waitForLoad(msg.ch))
+
+	case fetchedBody:
+		if msg.load != m.load {
+			break
+		}
+		if msg.err != nil {
+			slog.Error("fetching release", "tag", msg.tag, "err", msg.err)
+			break
+		}
+
+		m.raw.Put(msg.tag, msg.body)
+		if m.breaking != nil {
+			m.breaking[msg.tag] = releases.IsBreaking(msg.body)
+		}
+		if m.focus >= 0 && m.tagList[m.focus].Original() == msg.tag {
+			cmds = append(cmds, m.showFocused())
+		}
+
+	case bodiesFetched:
+		if msg.load != m.load {
+			break
+		}
+		for tag, body := range msg.bodies {
+			m.raw.Put(tag, body)
+		}
+
+		switch msg.purpose {
+		case refetchPeople:
+			if m.people.open {
+				cmds = append(cmds, m.openPeople(m.people.mentions, msg.bodies))
+			}
+		case refetchSearch:
+			if m.search.query != "" {
+				m.search.hits, _ = m.findHits(m.search.query, msg.bodies)
+				m.search.current = -1
+				cmds = append(cmds, m.nextHit(1))
+			}
+		case refetchAggregate:
+			if m.aggregate {
+				cmds = append(cmds, m.openAggregate(msg.bodies))
+			}
+		case refetchCopy:
+			cmds = append(cmds, m.copyAggregate(msg.bodies))
+		case refetchDeprecations:
+			if m.deprecations.open {
+				cmds = append(cmds, m.openDeprecations(msg.bodies))
+			}
+		case refetchFilter:
+			if m.filter.pending != "" {
+				cmds = append(cmds, m.applyFilter(m.filter.pending, msg.bodies))
+			}
+		}
+
+	case milestonesLoaded:
+		for url, ms := range msg.milestones {
+			m.enriched.milestones[url] = ms
+		}
+
+		// re-render with the new details, unless they were looked up for
+		// another repository's notes
+		if msg.load != m.load {
+			break
+		}
+		m.rendered.Delete(msg.tag)
+		if m.focus >= 0 && m.tagList[m.focus].Original() == msg.tag {
+			cmds = append(cmds, m.showFocused())
+		}
+
+	case issuesLoaded:
+		for ref, issue := range msg.issues {
+			m.enriched.issues[ref] = issue
+		}
+
+		if msg.load != m.load {
+			break
+		}
+		m.rendered.Delete(msg.tag)
+		if m.focus >= 0 && m.tagList[m.focus].Original() == msg.tag {
+			cmds = append(cmds, m.showFocused())
+		}
+
+	case downloadProgress:
+		if msg.repo == m.repoKey() && m.transfer.asset == msg.asset {
+			m.transfer.done, m.transfer.total = msg.done, msg.total
+		}
+		cmds = append(cmds, waitForLoad(msg.ch))
+
+	case downloadDone:
+		if msg.repo != m.repoKey() {
+			break
+		}
+		m.transfer = transfer{}
+		m.status = downloadStatus(msg)
+
+	case commentsLoaded:
+		if msg.err != nil {
+			m.status = "could not load discussion: " + msg.err.Error()
+			if m.discussion.url == msg.url {
+				m.closeDiscussion()
+			}
+			break
+		}
+
+		m.enriched.comments[msg.url] = msg.comments
+		if m.discussion.open && m.discussion.url == msg.url {
+			m.discussion.loading = false
+			m.showComments(msg.comments)
+		}
+
+	case comparisonLoaded:
+		if msg.err != nil {
+			m.status = "could not load commits: " + msg.err.Error()
+			if m.commits.key == msg.key {
+				m.closeCommits()
+			}
+			break
+		}
+
+		m.enriched.comparisons[msg.key] = msg.comparison
+		if m.commits.open && m.commits.key == msg.key {
+			m.commits.loading = false
+			m.showCommits(msg.comparison)
+		}
+
+	case fileLoaded:
+		if !m.file.open || m.file.key != msg.key {
+			break
+		}
+		if msg.err != nil {
+			m.status = "could not fetch " + m.file.path + ": " + msg.err.Error()
+			m.closeFile()
+			break
+		}
+		m.showFile(msg.content)
+
+	case namesLoaded:
+		for login, name := range msg {
+			m.enriched.names[login] = name
+		}
+
+	case navSettled:
+		if msg.seq == m.navSeq {
+			cmds = append(cmds, m.showFocused())
+		}
+
+	case errMsg:
+		if msg.load != m.load {
+			break
+		}
+		// show what went wrong until it's retried, rather than quit
+		m.failure = msg.err
+		m.loadingMore = false
+
+	case statusMsg:
+		m.status = string(msg)
+
+	case scopesChecked:
+		m.scopes = tokenScopes(msg)
+
+	case tea.KeyMsg:
+		m.status = ""
+
+		if m.search.active {
+			return m.updateSearchInput(msg)
+		}
+		if m.switcher.active {
+			return m.updateSwitcher(msg)
+		}
+		if m.jump.active {
+			return m.updateJump(msg)
+		}
+		if m.filter.active {
+			return m.updateFilter(msg)
+		}
+		if m.failure != nil {
+			return m.updateFailure(msg)
+		}
+
+		if m.assets.open {
+			return m.updateAssets(msg)
+		}
+		if m.people.open {
+			return m.updatePeople(msg)
+		}
+		if m.deprecations.open {
+			return m.updateDeprecations(msg)
+		}
+		if m.discussion.open {
+			return m.updateDiscussion(msg)
+		}
+		if m.commits.open {
+			return m.updateCommits(msg)
+		}
+		if m.help {
+			return m.updateHelp(msg)
+		}
+		if m.aggregate {
+			return m.updateAggregate(msg)
+		}
+		if m.file.open {
+			return m.updateFile(msg)
+		}
+
+		if m.listLayout {
+			if cmd, ok := m.listKey(msg); ok {
+				return m, cmd
+			}
+		}
+
+		if m.scrollKey(msg) {
+			return m, nil
+		}
+
+		switch msg.String() {
+		case "ctrl+c", "q", "esc":
+			// exit the program
+			return m, tea.Quit
+
+		case "left", "h":
+			// navigate to previous release, skipping those filtered out
+			cmds = append(cmds, m.focusRelease(m.nextShown(m.focus, -1)))
+
+		case "R":
+			// cycle the review status of the focused release
+			if m.focus >= 0 {
+				key := m.repoKey()
+				tag := m.tagList[m.focus].Original()
+				if err := m.reviews.set(key, tag, m.reviews.status(key, tag).next()); err != nil {
+					slog.Error("saving review state", "err", err)
+				}
+			}
+
+		case "right", "l":
+			// navigate to next release, skipping those filtered out
+			cmds = append(cmds, m.focusRelease(m.nextShown(m.focus, 1)))
+
+		case "/":
+			// search tags, titles and notes
+			if m.loaded {
+				m.search.active = true
+				m.search.input.SetValue("")
+				cmds = append(cmds, m.search.input.Focus())
+			}
+
+		case "g":
+			// go straight to a tag
+			return m.openJump()
+
+		case "f":
+			// only step through releases mentioning a keyword
+			return m.openFilter()
+
+		case "end", "G":
+			// jump to the latest stable release
+			cmds = append(cmds, m.focusRelease(m.latestStable()))
+
+		case "home":
+			// jump to the first release loaded
+			cmds = append(cmds, m.focusRelease(0))
+
+		case "n":
+			// next match of the search, across releases
+			cmds = append(cmds, m.nextHit(1))
+
+		case "N":
+			// previous match of the search
+			cmds = append(cmds, m.nextHit(-1))
+
+		case "A":
+			// show the focused release's assets
+			if m.focus >= 0 {
+				m.assets = m.openAssets()
+			}
+
+		case "D":
+			// open the focused release's discussion thread
+			if r, ok := m.focusedRelease(); ok && r.DiscussionURL != "" {
+				cmds = append(cmds, openURL(r.DiscussionURL))
+			}
+
+		case "y":
+			// copy the focused release's notes
+			cmds = append(cmds, m.copyFocused())
+
+		case "Y":
+			// copy everything that changed since the current version
+			cmds = append(cmds, m.copyAggregate(nil))
+
+		case "a":
+			// read everything that changed since the current version
+			cmds = append(cmds, m.openAggregate(nil))
+
+		case "}":
+			// the next section of the notes
+			m.jumpSection(1)
+
+		case "{":
+			// the previous section
+			m.jumpSection(-1)
+
+		case "z":
+			// fold or unfold the section at the top of the notes
+			cmds = append(cmds, m.toggleSection())
+
+		case "tab", "]":
+			// focus the next link in the notes
+			m.cycleLink(1)
+
+		case "shift+tab", "[":
+			// focus the previous link
+			m.cycleLink(-1)
+
+		case "enter":
+			// follow the focused link
+			return m.followLink()
+
+		case "i":
+			// look at the images in the notes
+			cmds = append(cmds, m.previewImages())
+
+		case "c":
+			// list the commits since the previous tag
+			return m.openCommits()
+
+		case "?":
+			m.help = true
+			return m, nil
+
+		case "v":
+			// switch between the timeline and the release list
+			return m, m.toggleListLayout()
+
+		case "x":
+			// expand the issues and pull requests the notes refer to
+			return m, m.toggleExpandIssues()
+
+		case "m":
+			m.toggleMark()
+			return m, nil
+
+		case "d":
+			// compare the marked release with the focused one
+			return m.openDiff()
+
+		case "C":
+			// read the comments on the focused release's discussion
+			return m.openDiscussion()
+
+		case "P":
+			// show new contributors across the browsed range
+			if m.focus >= 0 {
+				cmds = append(cmds, m.openPeople(false, nil))
+			}
+
+		case "E":
+			// list what was deprecated across the browsed range; on E
+			// rather than D, which already opens discussion threads
+			if m.focus >= 0 {
+				cmds = append(cmds, m.openDeprecations(nil))
+			}
+
+		case "ctrl+p":
+			// jump to a pinned, aliased or recently browsed repo
+			return m.openSwitcher()
+
+		case "ctrl+t":
+			// open another repo in a new tab
+			m, cmd := m.openSwitcher()
+			m.switcher.newTab = true
+			return m, cmd
+
+		case "p":
+			// show or hide prereleases and drafts
+			cmds = append(cmds, m.togglePrereleases())
+
+		case "*":
+			// pin the current repo in the quick switcher
+			m.togglePin()
+
+		case "@":
+			// peek at who the focused release mentions
+			if m.focus >= 0 {
+				cmds = append(cmds, m.openPeople(true, nil))
+			}
+		}
+
+	case tea.WindowSizeMsg:
+		headerHeight := lipgloss.Height(m.headerView())
+		footerHeight := lipgloss.Height(m.footerView())
+		verticalMarginHeight := headerHeight + footerHeight
+
+		if !m.viewReady {
+			// Since this program is using the full size of the viewport we
+			// need to wait until we've received the window dimensions before
+			// we can initialize the viewport. The initial dimensions come in
+			// quickly, though asynchronously, which is why we wait for them
+			// here.
+			m.viewport = viewport.New(msg.Width, msg.Height-verticalMarginHeight)
+			m.viewport.KeyMap = pagerKeyMap()
+			if m.wheelLines > 0 {
+				m.viewport.MouseWheelDelta = m.wheelLines
+			}
+			m.viewport.YPosition = headerHeight
+			m.viewReady = true
+		} else {
+			resized := m.viewport.Width != msg.Width
+			m.viewport.Width = msg.Width
+			m.viewport.Height = msg.Height - verticalMarginHeight
+			if resized && m.listLayout {
+				cmds = append(cmds, m.rerender())
+			}
+		}
+
+	case tea.MouseMsg:
+		if m.loaded && m.failure == nil && !m.overlayOpen() {
+			if updated, cmd, handled := m.updateMouse(msg); handled {
+				return updated, cmd
+			}
+		}
+
+	default:
+		m.spinner, cmd = m.spinner.Update(msg)
+		cmds = append(cmds, cmd)
+
+	}
+
+	m.viewport, cmd = m.viewport.Update(msg)
+	cmds = append(cmds, cmd)
+
+	return m, tea.Batch(cmds...)
+}
+
+// focusRelease moves the focus to release i, if there is one.
+func (m *Model) focusRelease(i int) tea.Cmd {
+	if i < 0 || i >= len(m.tagList) || i == m.focus {
+		return nil
+	}
+	m.focus = i
+	return m.focusChanged()
+}
+
+// navDebounce is how long navigation has to pause before the focused
+// release's body is rendered.
+const navDebounce = 100 * time.Millisecond
+
+type navSettled struct{ seq int }
+
+// focusChanged is called after every navigation step. The header follows
+// focus immediately; the body is shown right away only if it's already
+// rendered, and otherwise once navigation has paused for navDebounce, so
+// holding an arrow key doesn't render every release along the way.
+func (m *Model) focusChanged() tea.Cmd {
+	m.navSeq++
+
+	if m.focus >= 0 {
+		tag := m.tagList[m.focus].Original()
+		if out, ok := m.rendered.Get(tag); ok {
+			m.showBody(tag, out)
+			return nil
+		}
+	}
+
+	seq := m.navSeq
+	return tea.Tick(navDebounce, func(time.Time) tea.Msg {
+		return navSettled{seq}
+	})
+}
+
+type fetchedBody struct {
+	load string
+	tag  string
+	body string
+	err  error
+}
+
+// render renders markdown in the configured style.
+func (m Model) render(md string) string {
+	var (
+		out string
+		err error
+	)
+	if m.listLayout {
+		out, err = m.renderWrapped(md, min(80, m.notesWidth()-2))
+	} else {
+		out, err = glamour.Render(md, m.style)
+	}
+	if err != nil {
+		slog.Error("rendering notes", "err", err)
+		return md
+	}
+	return out
+}
+
+// showFocused puts the focused release's notes in the viewport, rendering
+// them if the rendered cache no longer has them. If the raw body was
+// evicted too, it's fetched again and shown when it arrives.
+func (m *Model) showFocused() tea.Cmd {
+	if m.focus < 0 || m.discussion.open || m.commits.open || m.file.open || m.aggregate {
+		return nil
+	}
+
+	tag := m.tagList[m.focus].Original()
+
+	if out, ok := m.rendered.Get(tag); ok {
+		m.showBody(tag, out)
+		return nil
+	}
+
+	if body, ok := m.raw.Get(tag); ok {
+		if strings.TrimSpace(body) == "" {
+			body = m.emptyNotes()
+		}
+		body, cmd := m.enrich(tag, body)
+		out := m.render(body)
+		m.rendered.Put(tag, out)
+		m.showBody(tag, out)
+		return cmd
+	}
+
+	getter, ok := m.provider.(releases.Getter)
+	if !ok {
+		return nil
+	}
+
+	m.setContent("")
+	owner, repo, load := m.owner, m.repo, m.load
+	return func() tea.Msg {
+		r, err := getter.GetRelease(context.Background(), owner, repo, tag)
+		return fetchedBody{load: load, tag: tag, body: r.Description, err: err}
+	}
+}
+
+func (m Model) View() string {
+	return fmt.Sprintf("%s\n%s\n%s", m.headerView(), m.bodyView(), m.footerView())
+}
+
+func (m Model) Title() string {
+	title := fmt.Sprintf(" %s/%s Releases", m.owner, m.repo)
+	if m.pinned(m.repoKey()) {
+		title += " ★"
+	}
+	if m.behindKnown {
+		title += " · " + m.behind.String()
+	}
+	if m.constraint != nil {
+		title += " · " + m.constraintSpec
+	}
+	if m.filter.keyword != "" {
+		title += " · mentioning " + m.filter.keyword
+	}
+
+	warning := ""
+	if m.offline != nil && m.offline() {
+		warning = offlineStyle.Render(" (cached, offline) ")
+	}
+	if m.staleWarning != "" {
+		warning += warningStyle.Render(" " + m.staleWarning + " ")
+	}
+	if beyond := m.beyondConstraint(); beyond != "" {
+		warning += warningStyle.Render(" " + beyond + " ")
+	}
+
+	title += strings.Repeat(" ", max(0, m.viewport.Width-lipgloss.Width(title)-lipgloss.Width(warning)))
+
+	return titleStyle.Render(title) + warning
+}
+
+func (m Model) repoKey() string {
+	return fmt.Sprintf("%s/%s", m.owner, m.repo)
+}
+
+func (m Model) headerView() string {
+	version := ""
+	rendered := fmt.Sprintf("\n%s\n", strings.Repeat("─", max(0, m.viewport.Width)))
+
+	if m.focus >= 0 {
+		tag := m.tagList[m.focus]
+		version = m.releaseLabel(tag.Original(), max(m.viewport.Width/2, 20))
+
+		if status := m.reviews.status(m.repoKey(), tag.Original()); status != unreviewed {
+			version = fmt.Sprintf("%s %s %s", version, status.marker(), status)
+		}
+
+		tagLabel := tagStyle.Render(version)
+		line := strings.Repeat("─", max(0, m.viewport.Width-lipgloss.Width(tagLabel)))
+
+		if r, ok := m.focusedRelease(); ok && r.DiscussionURL != "" {
+			discussion := infoStyle.Render("C comments · D discussion")
+			line = strings.Repeat("─", max(0, m.viewport.Width-lipgloss.Width(tagLabel)-lipgloss.Width(discussion)))
+			rendered = lipgloss.JoinHorizontal(lipgloss.Center, tagLabel, line, discussion)
+		} else {
+			rendered = lipgloss.JoinHorizontal(lipgloss.Center, tagLabel, line)
+		}
+	}
+
+	return fmt.Sprintf("%s\n%s\n%s\n%s", m.Title(), m.releaseList(), rendered, m.metadataView())
+}
+
+func (m Model) bodyView() string {
+	if m.switcher.active {
+		return m.switcherView()
+	}
+
+	if m.help {
+		return m.helpView()
+	}
+
+	if m.failure != nil {
+		return m.failureView()
+	}
+
+	if m.loaded && m.assets.open {
+		return m.assetsView()
+	}
+
+	if m.loaded && m.people.open {
+		return m.peopleView()
+	}
+
+	if m.loaded && m.deprecations.open {
+		return m.deprecationsView()
+	}
+
+	if m.loaded && m.discussion.loading {
+		content := fmt.Sprintf("%s loading comments...", m.spinner.View())
+		return screenCentered(m.viewport.Width, m.viewport.Height).Render(content)
+	}
+
+	if m.loaded && m.file.loading {
+		content := fmt.Sprintf("%s loading %s...", m.spinner.View(), m.file.path)
+		return screenCentered(m.viewport.Width, m.viewport.Height).Render(content)
+	}
+
+	if m.loaded && m.commits.loading {
+		content := fmt.Sprintf("%s loading commits...", m.spinner.View())
+		return screenCentered(m.viewport.Width, m.viewport.Height).Render(content)
+	}
+
+	if m.loaded && m.listLayout {
+		return m.listView()
+	}
+
+	if m.loaded {
+		return m.linkify(m.stickyTitle(m.highlightLink(m.highlight(m.viewport.View())), m.viewport.Width), m.viewport.Width)
+	} else {
+		content := fmt.Sprintf("%s loading...", m.spinner.View())
+		if m.loadTotal > 1 {
+			bar := progress.New(progress.WithDefaultGradient(), progress.WithWidth(min(40, m.viewport.Width)))
+			content += fmt.Sprintf("\n\n%s\n%d of %d pages", bar.ViewAs(float64(m.loadDone)/float64(m.loadTotal)), m.loadDone, m.loadTotal)
+		}
+		return screenCentered(m.viewport.Width, m.viewport.Height).Render(content)
+	}
+}
+
+func (m Model) footerView() string {
+	if m.search.active {
+		return fmt.Sprintf("\n%s\n", m.search.input.View())
+	}
+	if m.switcher.active {
+		return fmt.Sprintf("\n%s\n", m.switcher.input.View())
+	}
+	if m.jump.active {
+		return fmt.Sprintf("\n%s\n", m.jumpView())
+	}
+	if m.filter.active {
+		return fmt.Sprintf("\n%s\n", m.filter.input.View())
+	}
+
+	status := m.status
+	if m.transfer.asset != "" && status == "" {
+		status = m.transferView()
+	}
+	if m.loadingMore && status == "" {
+		status = m.loadingMoreView()
+	}
+
+	if status != "" {
+		return m.footerInfo(status)
+	}
+
+	quota := m.quotaView()
+
+	if m.viewport.VisibleLineCount() >= m.viewport.TotalLineCount() {
+		if quota == "" {
+			return fmt.Sprintf("\n%s\n", strings.Repeat("─", m.viewport.Width))
+		}
+		return m.footerInfo(quota)
+	}
+
+	text := fmt.Sprintf("%3.f%%", m.viewport.ScrollPercent()*100)
+	if quota != "" {
+		text = quota + " · " + text
+	}
+	return m.footerInfo(text)
+}
+
+// footerInfo draws text boxed at the right end of the footer's rule. It's
+// cut to fit: a footer wider than the terminal would wrap onto another
+// line, which the viewport's height doesn't leave room for.
+func (m Model) footerInfo(text string) string {
+	room := m.viewport.Width - lipgloss.Width(infoStyle.Render(""))
+	info := infoStyle.Render(reflowtruncate.StringWithTail(text, uint(max(0, room)), "…"))
+	line := strings.Repeat("─", max(0, m.viewport.Width-lipgloss.Width(info)))
+	return lipgloss.JoinHorizontal(lipgloss.Center, line, info)
+}
+
+// loadingMoreView tells that releases are still arriving.
+func (m Model) loadingMoreView() string {
+	if m.loadTotal > 0 {
+		return fmt.Sprintf("%s loading more… %d of %d pages", m.spinner.View(), m.loadDone, m.loadTotal)
+	}
+	return fmt.Sprintf("%s loading more… %d pages", m.spinner.View(), m.loadDone)
+}
+
+// quotaView shows the API requests left, and when they run low, when the
+// quota resets.
+func (m Model) quotaView() string {
+	if m.rateLimit == nil {
+		return ""
+	}
+	remaining, limit, reset, ok := m.rateLimit()
+	if !ok {
+		return ""
+	}
+
+	text := fmt.Sprintf("API %d/%d", remaining, limit)
+	switch {
+	case remaining == 0:
+		return warningStyle.Render(fmt.Sprintf("rate limit reached, resets %s", reset.Local().Format("15:04")))
+	case limit > 0 && remaining*10 < limit:
+		text += fmt.Sprintf(", resets %s", reset.Local().Format("15:04"))
+	}
+	return text
+}